@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// hiddenAbilityOdds is the chance, 1 in hiddenAbilityOdds, of a wild Pokemon
+// having its hidden ability.
+const hiddenAbilityOdds = 100
+
+// rollAbility picks the ability of a new individual: usually one of the
+// species' regular abilities, occasionally its hidden one.
+func rollAbility(pokemon pokeapi.Pokemon, rng *rand.Rand) pokeapi.PokemonAbility {
+	regular, hidden := []pokeapi.PokemonAbility{}, []pokeapi.PokemonAbility{}
+	for _, ability := range pokemon.Abilities {
+		if ability.IsHidden {
+			hidden = append(hidden, ability)
+		} else {
+			regular = append(regular, ability)
+		}
+	}
+	if len(hidden) > 0 && (len(regular) == 0 || rng.IntN(hiddenAbilityOdds) == 0) {
+		return hidden[0]
+	}
+	if len(regular) == 0 {
+		return pokeapi.PokemonAbility{}
+	}
+	return regular[rng.IntN(len(regular))]
+}
+
+// abilityInSlot returns the ability pokemon has in slot, falling back to its
+// first ability, so that an evolving Pokemon keeps the matching ability.
+func abilityInSlot(pokemon pokeapi.Pokemon, slot int) pokeapi.PokemonAbility {
+	for _, ability := range pokemon.Abilities {
+		if ability.Slot == slot {
+			return ability
+		}
+	}
+	if len(pokemon.Abilities) == 0 {
+		return pokeapi.PokemonAbility{}
+	}
+	return pokemon.Abilities[0]
+}
+
+// abilityEffect returns the short English description of the ability's
+// effect.
+func abilityEffect(ability pokeapi.Ability) string {
+	for _, entry := range ability.EffectEntries {
+		if entry.Language.Name == "en" {
+			return entry.ShortEffect
+		}
+	}
+	return ""
+}
+
+func commandAbility(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("ability <name>")
+	}
+	ability, err := pokeapi.GetAbility(args[1], config.Cache)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Name:", ability.Name)
+	if effect := abilityEffect(ability); effect != "" {
+		fmt.Println("Effect:", effect)
+	}
+	fmt.Println("Pokemon:")
+	for _, pokemon := range ability.Pokemon {
+		hidden := ""
+		if pokemon.IsHidden {
+			hidden = " (hidden)"
+		}
+		fmt.Println("  -", localPokemonName(config, pokemon.Pokemon.Name)+hidden)
+	}
+	return nil
+}
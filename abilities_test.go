@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestRollAbility(t *testing.T) {
+	bulbasaur := pokeapi.Pokemon{
+		Abilities: []pokeapi.PokemonAbility{
+			{Slot: 1, Ability: pokeapi.Resource{Name: "overgrow"}},
+			{Slot: 3, IsHidden: true, Ability: pokeapi.Resource{Name: "chlorophyll"}},
+		},
+	}
+	rng := newRNG(7)
+	hidden := 0
+	trials := 100 * hiddenAbilityOdds
+	for range trials {
+		ability := rollAbility(bulbasaur, rng)
+		if ability.IsHidden {
+			hidden += 1
+		} else if ability.Ability.Name != "overgrow" {
+			t.Fatalf("[Expected, Received]: ['overgrow', '%s']", ability.Ability.Name)
+		}
+	}
+	// Expect about 100 hidden abilities.
+	if hidden < 70 || hidden > 130 {
+		t.Errorf("[Expected, Received]: [about 100, %d]", hidden)
+	}
+}
+
+func TestAbilityInSlot(t *testing.T) {
+	ivysaur := pokeapi.Pokemon{
+		Abilities: []pokeapi.PokemonAbility{
+			{Slot: 1, Ability: pokeapi.Resource{Name: "overgrow"}},
+			{Slot: 3, IsHidden: true, Ability: pokeapi.Resource{Name: "chlorophyll"}},
+		},
+	}
+	cases := []struct {
+		slot     int
+		expected string
+	}{
+		{slot: 1, expected: "overgrow"},
+		{slot: 3, expected: "chlorophyll"},
+		{slot: 2, expected: "overgrow"},
+	}
+	for _, c := range cases {
+		if actual := abilityInSlot(ivysaur, c.slot).Ability.Name; actual != c.expected {
+			t.Errorf("slot %d: [Expected, Received]: ['%s', '%s']", c.slot, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Bag maps item names, as used by the API (e.g. "great-ball"), to the
+// quantity carried.
+type Bag map[string]int
+
+func defaultBag() Bag {
+	return Bag{
+		"poke-ball":     20,
+		"great-ball":    10,
+		"ultra-ball":    5,
+		"premier-ball":  1,
+		"luxury-ball":   3,
+		"heal-ball":     3,
+		"net-ball":      2,
+		"dive-ball":     2,
+		"nest-ball":     2,
+		"repeat-ball":   2,
+		"timer-ball":    2,
+		"quick-ball":    2,
+		"dusk-ball":     2,
+		"level-ball":    2,
+		"heavy-ball":    2,
+		"fast-ball":     2,
+		"moon-ball":     2,
+		"love-ball":     2,
+		"fire-stone":    1,
+		"water-stone":   1,
+		"thunder-stone": 1,
+		"leaf-stone":    1,
+		"moon-stone":    1,
+	}
+}
+
+// take removes one of item from the bag, reporting whether there was one to
+// remove.
+func (bag Bag) take(item string) bool {
+	if bag[item] <= 0 {
+		return false
+	}
+	bag[item] -= 1
+	if bag[item] == 0 {
+		delete(bag, item)
+	}
+	return true
+}
+
+// itemName converts an API item name such as "great-ball" to its display name.
+func itemName(item string) string {
+	words := strings.Split(item, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func commandBag(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usage("bag [--json]")
+	}
+	if jsonOutput(config, flags) {
+		return printJSON(config.Bag)
+	}
+	if len(config.Bag) == 0 {
+		fmt.Println("Your bag is empty.")
+		return nil
+	}
+	items := []string{}
+	for item := range config.Bag {
+		items = append(items, item)
+	}
+	slices.Sort(items)
+	fmt.Println("Your bag:")
+	for _, item := range items {
+		fmt.Printf("  - %s x%d\n", itemName(item), config.Bag[item])
+	}
+	return nil
+}
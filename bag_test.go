@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestBagTake(t *testing.T) {
+	bag := Bag{"poke-ball": 2}
+	for i := 0; i < 2; i++ {
+		if !bag.take("poke-ball") {
+			t.Errorf("expected to take a poke-ball")
+			return
+		}
+	}
+	if bag.take("poke-ball") {
+		t.Errorf("expected the bag to be out of poke-balls")
+	}
+	if _, ok := bag["poke-ball"]; ok {
+		t.Errorf("expected empty items to be removed from the bag")
+	}
+}
+
+func TestItemName(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "poke-ball", expected: "Poke Ball"},
+		{input: "great-ball", expected: "Great Ball"},
+		{input: "master-ball", expected: "Master Ball"},
+	}
+	for _, c := range cases {
+		if actual := itemName(c.input); actual != c.expected {
+			t.Errorf("[Expected, Received]: ['%s', '%s']", c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/jthughes/pokedexcli/internal/battle"
+	"github.com/jthughes/pokedexcli/internal/capture"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// battleState is a battle in progress against config.Wild.
+type battleState struct {
+	engine *battle.Battle
+	// team holds the party indices of engine.Team, in the same order.
+	team []int
+}
+
+// battleTeam returns the party indices of the trainer's Pokemon able to
+// battle, in party order.
+func battleTeam(config *Config) []int {
+	team := []int{}
+	for i, pokemon := range config.Party {
+		if pokemon.CurrentHP > 0 {
+			team = append(team, i)
+		}
+	}
+	return team
+}
+
+func battleMoves(config *Config, names []string) ([]*battle.Move, error) {
+	moves := []*battle.Move{}
+	for _, name := range names {
+		move, err := pokeapi.GetMove(name, config.Cache)
+		if err != nil {
+			return nil, err
+		}
+		battleMove := &battle.Move{
+			Name:      move.Name,
+			Type:      move.Type.Name,
+			Class:     move.DamageClass.Name,
+			PP:        move.PP,
+			MaxPP:     move.PP,
+			Priority:  move.Priority,
+			CritStage: move.Meta.CritRate,
+		}
+		if move.Power != nil {
+			battleMove.Power = *move.Power
+		}
+		if move.Accuracy != nil {
+			battleMove.Accuracy = *move.Accuracy
+		}
+		moves = append(moves, battleMove)
+	}
+	return moves, nil
+}
+
+func newCombatant(config *Config, pokemon pokeapi.Pokemon, name string, level int, stat func(string) int,
+	currentHP int, status capture.Status, moveNames []string) (*battle.Combatant, error) {
+	moves, err := battleMoves(config, moveNames)
+	if err != nil {
+		return nil, err
+	}
+	combatant := &battle.Combatant{
+		Name:   name,
+		Level:  level,
+		Stats:  map[string]int{},
+		HP:     currentHP,
+		MaxHP:  stat("hp"),
+		Status: string(status),
+		Moves:  moves,
+	}
+	combatant.Types = pokemonTypes(pokemon, config.Settings.Generation)
+	for _, name := range statNames {
+		combatant.Stats[name] = stat(name)
+	}
+	if status == capture.Sleep {
+		combatant.SleepTurns = 1 + config.RNG.IntN(3)
+	}
+	return combatant, nil
+}
+
+func commandBattle(config *Config, args []string) error {
+	if len(args) != 1 {
+		return usage("battle")
+	}
+	if config.Battle != nil {
+		return errors.New("You are already in a battle!")
+	}
+	wild := config.Wild
+	if wild == nil {
+		return errors.New("There is no wild Pokemon to battle. Try walking to find one.")
+	}
+	team := battleTeam(config)
+	if len(team) == 0 {
+		return errors.New("You have no Pokemon able to battle!")
+	}
+
+	combatants := []*battle.Combatant{}
+	for _, index := range team {
+		pokemon := &config.Party[index]
+		if len(pokemon.KnownMoves) == 0 {
+			pokemon.KnownMoves = knownMoves(pokemon.Pokemon, pokemon.Level)
+		}
+		combatant, err := newCombatant(config, pokemon.Pokemon, pokemon.displayName(config), pokemon.Level, pokemon.stat,
+			pokemon.CurrentHP, pokemon.Status, pokemon.KnownMoves)
+		if err != nil {
+			return err
+		}
+		combatants = append(combatants, combatant)
+	}
+	opponent, err := newCombatant(config, wild.Pokemon, wild.displayName(config), wild.Level, wild.stat,
+		wild.CurrentHP, wild.Status, wild.KnownMoves)
+	if err != nil {
+		return err
+	}
+	chart, err := getTypeChart(config)
+	if err != nil {
+		return err
+	}
+	engine, err := battle.New(combatants, opponent, chart.chart, config.RNG)
+	if err != nil {
+		return err
+	}
+	config.Battle = &battleState{engine: engine, team: team}
+	fmt.Println("You challenged the wild " + wild.describe(config) + "!")
+	fmt.Println("Go! " + engine.Player().Name + "!")
+	printBattleMoves(engine.Player())
+	return nil
+}
+
+func printBattleMoves(combatant *battle.Combatant) {
+	fmt.Printf("%s (Lv. %d) HP %d/%d\n", combatant.Name, combatant.Level, combatant.HP, combatant.MaxHP)
+	for i, move := range combatant.Moves {
+		fmt.Printf("  %d. %s (%s, %d/%d PP)\n", i+1, move.Name, move.Type, move.PP, move.MaxPP)
+	}
+}
+
+// battleTurn plays out a turn of the battle in progress and handles its
+// outcome.
+func battleTurn(config *Config, action battle.Action) error {
+	state := config.Battle
+	log, err := state.engine.Turn(action)
+	if err != nil {
+		return err
+	}
+	for _, line := range log {
+		fmt.Println(line)
+	}
+
+	// Write the battle back to the trainer's Pokemon and the wild Pokemon.
+	for i, index := range state.team {
+		pokemon := &config.Party[index]
+		pokemon.CurrentHP = state.engine.Team[i].HP
+		pokemon.Status = capture.Status(state.engine.Team[i].Status)
+	}
+	config.Wild.CurrentHP = state.engine.Wild.HP
+	config.Wild.Status = capture.Status(state.engine.Wild.Status)
+
+	switch state.engine.Outcome {
+	case battle.Won:
+		wild := config.Wild
+		recipients := yieldRecipients(config)
+		config.Battle = nil
+		config.Wild = nil
+		return awardYield(config, wild, recipients)
+	case battle.Lost:
+		config.Battle = nil
+		config.Wild = nil
+		fmt.Println("You blacked out! You hurry to a Pokemon Center.")
+		healAll(config)
+	case battle.Fled:
+		config.Battle = nil
+		config.Wild = nil
+	default:
+		if state.engine.NeedsSwitch {
+			fmt.Println("Choose the next Pokemon to send out: switch <pokemon>")
+		} else {
+			printBattleMoves(state.engine.Player())
+		}
+	}
+	return nil
+}
+
+func commandFight(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("fight <move>")
+	}
+	if config.Battle == nil {
+		return errors.New("You aren't in a battle.")
+	}
+	moves := config.Battle.engine.Player().Moves
+	index := slices.IndexFunc(moves, func(move *battle.Move) bool {
+		return move.Name == args[1]
+	})
+	if n, err := strconv.Atoi(args[1]); err == nil {
+		index = n - 1
+	}
+	if index < 0 || index >= len(moves) {
+		names := []string{}
+		for _, move := range moves {
+			names = append(names, move.Name)
+		}
+		return suggest(config.Battle.engine.Player().Name+" doesn't know "+args[1]+".", args[1], names)
+	}
+	return battleTurn(config, battle.Action{Kind: battle.Fight, Index: index})
+}
+
+func commandSwitch(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("switch <pokemon>")
+	}
+	if config.Battle == nil {
+		return errors.New("You aren't in a battle.")
+	}
+	index := slices.Index(config.Battle.team, partyIndex(config, args[1]))
+	if index < 0 {
+		return suggest(args[1]+" isn't able to battle.", args[1], ownedNames(config))
+	}
+	return battleTurn(config, battle.Action{Kind: battle.Switch, Index: index})
+}
+
+// healAll restores the trainer's party to full health.
+func healAll(config *Config) {
+	for i := range config.Party {
+		config.Party[i].CurrentHP = config.Party[i].maxHP()
+		config.Party[i].Status = capture.StatusNone
+	}
+}
+
+func commandHeal(config *Config, args []string) error {
+	if len(args) != 1 {
+		return usage("heal")
+	}
+	if config.Battle != nil {
+		return errors.New("You can't visit a Pokemon Center in the middle of a battle!")
+	}
+	healAll(config)
+	fmt.Println("Your Pokemon have been restored to full health.")
+	return nil
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"math"
-	"math/rand/v2"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/jthughes/pokedexcli/internal/battle"
+	"github.com/jthughes/pokedexcli/internal/capture"
 	"github.com/jthughes/pokedexcli/internal/pokeapi"
 )
 
@@ -20,117 +24,262 @@ func commandHelp(config *Config, args []string) error {
 	return nil
 }
 
-func commandMap(url *string, config *Config) error {
+// mapPageSize is how many location areas are listed on a page of the map.
+const mapPageSize = 20
+
+func commandMap(url *string, config *Config, asJSON bool) error {
 	locations, err := pokeapi.GetResourceList(url, config.Cache)
 	if err != nil {
 		return err
 	}
 	config.Next = locations.Next
 	config.Previous = locations.Previous
+	if asJSON {
+		names := []string{}
+		for _, location := range locations.Results {
+			names = append(names, location.Name)
+		}
+		return printJSON(names)
+	}
 	for _, location := range locations.Results {
-		fmt.Println(location.Name)
+		fmt.Println(localAreaName(config, location.Name))
 	}
 	return nil
 }
 
 func commandMapForward(config *Config, args []string) error {
-	return commandMap(config.Next, config)
+	positional, flags, err := parseFlags(args[1:], "page")
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usage("map [--page n] [--json]")
+	}
+	url := config.Next
+	if flags["page"] != "" {
+		page, err := strconv.Atoi(flags["page"])
+		if err != nil || page < 1 {
+			return fmt.Errorf("expecting a page number, got %q", flags["page"])
+		}
+		pageURL := pokeapi.PageURL("location-area", page, mapPageSize)
+		url = &pageURL
+	}
+	return commandMap(url, config, jsonOutput(config, flags))
 }
 
 func commandMapBack(config *Config, args []string) error {
+	_, flags, err := parseFlags(args[1:])
+	if err != nil {
+		return err
+	}
 	if config.Previous == nil {
 		fmt.Println("you're on the first page")
 		return nil
 	}
-	return commandMap(config.Previous, config)
+	return commandMap(config.Previous, config, jsonOutput(config, flags))
 }
 
 func commandExplore(config *Config, args []string) error {
-	if len(args) != 2 {
-		fmt.Println("Expecting: explore <location-area>")
-		return nil
+	if len(args) > 2 {
+		return usage("explore [location-area]")
 	}
-	locationArea := args[1]
-	fmt.Println("Exploring " + locationArea + "...")
+	locationArea, err := currentArea(config, args[1:])
+	if err != nil {
+		return err
+	}
+	fmt.Println("Exploring " + localAreaName(config, locationArea) + "...")
 	pokemonList, err := pokeapi.GetPokemonList(locationArea, config.Cache)
 	if err != nil {
 		return err
 	}
 	fmt.Println("Found Pokemon:")
 	for _, encounter := range pokemonList {
-		fmt.Println(" - " + encounter.Pokemon.Name)
+		fmt.Println(" - " + localPokemonName(config, encounter.Pokemon.Name))
 	}
 	return nil
 }
 
 type Pokemon struct {
 	pokeapi.Pokemon
-	Species pokeapi.PokemonSpecies
+	Species    pokeapi.PokemonSpecies
+	Ball       string
+	Friendship int
+	Level      int
+	Experience int
+	IVs        Stats
+	EVs        Stats
+	Nature     Nature
+	Ability    pokeapi.PokemonAbility
+	Gender     string
+	Shiny      bool
+	KnownMoves []string
+	CurrentHP  int
+	Status     capture.Status
+}
+
+func (pokemon *Pokemon) stat(name string) int {
+	return computeStat(pokemon.Pokemon, name, pokemon.IVs, pokemon.EVs, pokemon.Level, pokemon.Nature)
+}
+
+func (pokemon *Pokemon) maxHP() int {
+	return pokemon.stat("hp")
+}
+
+// addFriendship raises the Pokemon's friendship by amount. Pokemon caught in a
+// Luxury Ball gain an extra point each time, as in the main series.
+func (pokemon *Pokemon) addFriendship(amount int) {
+	if pokemon.Ball == "luxury-ball" && amount > 0 {
+		amount += 1
+	}
+	pokemon.Friendship = min(max(pokemon.Friendship+amount, 0), 255)
 }
 
 func commandPokedex(config *Config, args []string) error {
-	if len(args) != 1 {
-		fmt.Println("Expecting: pokedex")
-		return nil
+	positional, flags, err := parseFlags(args[1:], "dex")
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usage("pokedex [--dex <pokedex>] [--json]")
+	}
+	if dex, ok := flags["dex"]; ok {
+		return commandRegionalPokedex(config, dex, jsonOutput(config, flags))
+	}
+	names := slices.Sorted(maps.Keys(config.Pokedex))
+	if jsonOutput(config, flags) {
+		entries := []pokedexEntrySummary{}
+		for _, name := range names {
+			entries = append(entries, summarizePokedexEntry(config, 0, name))
+		}
+		return printJSON(entries)
 	}
 	if len(config.Pokedex) == 0 {
 		fmt.Println("The Pokedex is empty. Catch some Pokemon!")
 		return nil
 	}
 	fmt.Println("Your Pokedex:")
-	for name := range config.Pokedex {
-		fmt.Println("  -", name)
+	caught, shiny := 0, 0
+	for _, name := range names {
+		entry := config.Pokedex[name]
+		switch {
+		case entry.Caught == 0:
+			fmt.Printf("  - %s (owned by evolving)\n", localSpeciesName(config, name))
+		case entry.Shiny > 0:
+			fmt.Printf("  - %s (caught %d, %d shiny ★)\n", localSpeciesName(config, name), entry.Caught, entry.Shiny)
+			caught += 1
+			shiny += 1
+		default:
+			fmt.Printf("  - %s (caught %d)\n", localSpeciesName(config, name), entry.Caught)
+			caught += 1
+		}
+	}
+	fmt.Printf("Species caught: %d, caught shiny: %d\n", caught, shiny)
+	return nil
+}
+
+func commandRegionalPokedex(config *Config, dexName string, asJSON bool) error {
+	pokedex, err := pokeapi.GetPokedex(dexName, config.Cache)
+	if err != nil {
+		return err
 	}
+	if asJSON {
+		entries := []pokedexEntrySummary{}
+		for _, entry := range pokedex.PokemonEntries {
+			entries = append(entries, summarizePokedexEntry(config, entry.EntryNumber, entry.PokemonSpecies.Name))
+		}
+		return printJSON(entries)
+	}
+	caught, shiny := 0, 0
+	fmt.Println("Pokedex " + pokedex.Name + ":")
+	for _, entry := range pokedex.PokemonEntries {
+		status := ""
+		registered, ok := config.Pokedex[entry.PokemonSpecies.Name]
+		if ok && registered.Caught == 0 {
+			status = " (owned)"
+		} else if ok {
+			status = " (caught)"
+			caught += 1
+			if registered.Shiny > 0 {
+				status = " (caught ★)"
+				shiny += 1
+			}
+		}
+		fmt.Printf("  #%03d %s%s\n", entry.EntryNumber, localSpeciesName(config, entry.PokemonSpecies.Name), status)
+	}
+	fmt.Printf("Caught %d of %d, %d shiny\n", caught, len(pokedex.PokemonEntries), shiny)
 	return nil
 }
 
 func commandCatch(config *Config, args []string) error {
-	pokeballs := map[string]float64{
-		"Poke Ball":    1.0,
-		"Great Ball":   1.5,
-		"Ultra Ball":   2.0,
-		"Safari Ball":  1.5,
-		"Premier Ball": 1.0,
-		"Luxury Ball":  1.0,
-		"Heal Ball":    1.0,
-		"Cherish Ball": 1.0,
-	}
-	if len(args) != 2 {
-		fmt.Println("Expecting: catch <pokemon>")
-		return nil
+	if len(args) != 2 && len(args) != 3 {
+		return usage("catch <pokemon> [ball]")
 	}
 	pokemonName := args[1]
-	pokemon, err := pokeapi.GetPokemon(pokemonName, config.Cache)
-	if err != nil {
-		return err
+	ballName := "poke-ball"
+	if len(args) == 3 {
+		ballName = args[2]
+	}
+	ball, ok := pokeballs[ballName]
+	if !ok {
+		return suggest("Unknown ball: "+ballName, ballName, slices.Collect(maps.Keys(pokeballs)))
+	}
+	if config.Bag[ballName] <= 0 {
+		return errors.New("You don't have any " + itemName(ballName) + "s left!")
+	}
+	facing := config.Wild != nil &&
+		nameMatches(config, config.Wild.Species.Names, config.Wild.Pokemon.Name, pokemonName)
+	if !facing && !config.Settings.FreeCatch {
+		candidates := []string{}
+		if config.Wild != nil {
+			candidates = append(candidates, config.Wild.displayName(config))
+		}
+		return suggest("There is no wild "+pokemonName+" in front of you. Try walking to find one.", pokemonName, candidates)
 	}
-	pokemonSpecies, err := pokeapi.GetPokemonSpecies(pokemonName, config.Cache)
+	if !facing && config.Battle != nil {
+		return errors.New("You're in a battle with the wild " + config.Wild.displayName(config) + "! Catch it or run.")
+	}
+	if !facing {
+		apiName, err := speciesAPIName(config, pokemonName)
+		if err != nil {
+			return err
+		}
+		pokemon, err := pokeapi.GetPokemon(apiName, config.Cache)
+		if err != nil {
+			return err
+		}
+		pokemonSpecies, err := pokeapi.GetPokemonSpecies(apiName, config.Cache)
+		if err != nil {
+			return err
+		}
+		level := minWildLevel + config.RNG.IntN(maxWildLevel-minWildLevel+1)
+		config.Wild, err = spawnWild(config, config.RNG, pokemon, pokemonSpecies, level)
+		if err != nil {
+			return err
+		}
+		fmt.Println("A wild " + config.Wild.describe(config) + " appeared!")
+	}
+	if config.Battle != nil && config.Battle.engine.NeedsSwitch {
+		return errors.New("Send out another Pokemon first: switch <pokemon>")
+	}
+	if storageFull(config) {
+		return errors.New("There's no room left in your party or the PC!")
+	}
+	wild := config.Wild
+	pokemonName = wild.displayName(config)
+	config.Bag.take(ballName)
+	wild.Turn += 1
+	fmt.Println("Throwing a " + itemName(ballName) + " at " + pokemonName + "...")
+
+	result, err := throwBall(config, wild, ballName)
 	if err != nil {
 		return err
 	}
-	ball_type := "Poke Ball"
-	fmt.Println("Throwing a " + ball_type + " at " + pokemonName + "...")
-
-	pokeballRate := pokeballs[ball_type]
-	catchRate := (1.0 / 3.0) * float64(pokemonSpecies.CaptureRate) * pokeballRate
-	shakeRate := int(math.Floor(
-		1_048_560 / math.Floor(math.Sqrt(
-			math.Floor(math.Sqrt(
-				math.Floor(16_711_680/catchRate)))))))
-	shakes := [4]int{
-		rand.IntN(65_536),
-		rand.IntN(65_536),
-		rand.IntN(65_536),
-		rand.IntN(65_536),
-	}
-	shakeSuccesses := 0
-	for _, shake := range shakes[:3] {
-		if shake >= shakeRate {
-			break
-		}
-		shakeSuccesses += 1
+	if result.Critical {
+		fmt.Println("Critical capture!")
+	}
+	for range result.Shakes {
 		fmt.Println("*Shakes*")
-		time.Sleep(1500 * time.Millisecond)
+		config.Clock.Sleep(1500 * time.Millisecond)
 	}
 	shakeMessage := map[int]string{
 		0: "Oh, no!\nThe Pokemon broke free!",
@@ -138,40 +287,172 @@ func commandCatch(config *Config, args []string) error {
 		2: "Aargh!\nAlmost had it!",
 		3: "Shoot!\nIt was so close, too!",
 	}
-	if shakeSuccesses == 3 && shakes[3] < shakeRate {
+	if result.Caught {
 		fmt.Println("Gotcha! " + pokemonName + " was caught!")
-		if _, ok := config.Pokedex[pokemonName]; ok {
-			return nil
+		recipients := yieldRecipients(config)
+		config.Wild = nil
+		config.Battle = nil
+		caught := Pokemon{
+			Pokemon:    wild.Pokemon,
+			Species:    wild.Species,
+			Ball:       ballName,
+			Friendship: wild.Species.BaseHappiness,
+			Level:      wild.Level,
+			IVs:        wild.IVs,
+			EVs:        Stats{},
+			Nature:     wild.Nature,
+			Ability:    wild.Ability,
+			Gender:     wild.Gender,
+			Shiny:      wild.Shiny,
+			KnownMoves: wild.KnownMoves,
+			CurrentHP:  wild.CurrentHP,
+			Status:     wild.Status,
+		}
+		rate, err := pokeapi.GetGrowthRate(wild.Species.GrowthRate.Name, config.Cache)
+		if err != nil {
+			return err
+		}
+		caught.Experience = rate.Experience(caught.Level)
+		if ball.onCatch != nil {
+			ball.onCatch(config, &caught)
 		}
-		fmt.Println("Adding " + pokemonName + " to the Pokedex.")
-		config.Pokedex[pokemonName] = Pokemon{
-			Pokemon: pokemon,
-			Species: pokemonSpecies,
+		if err := awardYield(config, wild, recipients); err != nil {
+			return err
+		}
+		entry, ok := config.Pokedex[wild.Species.Name]
+		if !ok {
+			fmt.Println("Adding " + pokemonName + " to the Pokedex.")
+		}
+		entry.Caught += 1
+		if wild.Shiny {
+			entry.Shiny += 1
+		}
+		config.Pokedex[wild.Species.Name] = entry
+		box, err := store(config, caught)
+		if err != nil {
+			return err
+		}
+		if box > 0 {
+			fmt.Printf("%s was sent to Box %d on the PC.\n", pokemonName, box)
 		}
 	} else {
-		fmt.Println(shakeMessage[shakeSuccesses])
+		fmt.Println(shakeMessage[result.Shakes])
+		if config.Battle != nil {
+			// Throwing a ball uses up the trainer's turn.
+			return battleTurn(config, battle.Action{Kind: battle.Item})
+		}
 	}
 	return nil
 }
 
+// catchInput builds the input to the selected catch formula for throwing the
+// named ball at wild.
+func catchInput(config *Config, wild *wildPokemon, ballName string) (capture.Formula, capture.Input, error) {
+	ball := pokeballs[ballName]
+	formula, err := capture.Get(config.Settings.CatchFormula)
+	if err != nil {
+		return nil, capture.Input{}, err
+	}
+	context := catchContext{
+		pokemon: wild.Pokemon,
+		species: wild.Species,
+		pokedex: config.Pokedex,
+		turn:    wild.Turn,
+		now:     config.Clock.Now(),
+		level:   wild.Level,
+		method:  wild.Method,
+		gender:  wild.Gender,
+		cave:    isCave(config.Location),
+	}
+	if lead := leadPokemon(config); lead != nil {
+		context.leadLevel = lead.Level
+		context.leadSpecies = lead.Species.Name
+		context.leadGender = lead.Gender
+	}
+	return formula, capture.Input{
+		CatchRate:  ball.rate(context),
+		Ball:       ball.modifier(context),
+		BallName:   ballName,
+		MaxHP:      wild.maxHP(),
+		CurrentHP:  wild.CurrentHP,
+		Status:     wild.Status,
+		Registered: len(config.Pokedex),
+	}, nil
+}
+
+// throwBall throws the named ball at wild using the selected catch formula.
+func throwBall(config *Config, wild *wildPokemon, ballName string) (capture.Result, error) {
+	formula, input, err := catchInput(config, wild, ballName)
+	if err != nil {
+		return capture.Result{}, err
+	}
+	return formula.Throw(input, config.RNG), nil
+}
+
 func commandInspect(config *Config, args []string) error {
-	if len(args) != 2 {
-		fmt.Println("Expecting: inspect <pokemon>")
-		return nil
+	positional, flags, err := parseFlags(args[1:], "version")
+	if err != nil {
+		return err
 	}
-	pokemonName := args[1]
-	pokemon, ok := config.Pokedex[pokemonName]
-	if !ok {
-		fmt.Println(pokemonName + " has not been caught yet.")
-		return nil
+	if len(positional) != 1 {
+		return usage("inspect <pokemon> [--sprite] [--version <version>] [--all] [--json]")
+	}
+	found := findPokemon(config, positional[0])
+	if found == nil {
+		return suggest("You don't have "+positional[0]+" in your party or the PC.", positional[0], ownedNames(config))
+	}
+	pokemon := *found
+	if jsonOutput(config, flags) {
+		return printJSON(summarizePokemon(config, pokemon))
+	}
+	if flags["sprite"] == "true" {
+		if err := printSprite(config, pokemon.Pokemon, pokemon.Shiny, false, pokemon.Gender == "female"); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Name:", pokemon.displayName(config)+traitMarkers(pokemon.Gender, pokemon.Shiny))
+	if genus := genus(config, pokemon.Species); genus != "" {
+		fmt.Println("Genus:", genus)
+	}
+	printFlavorText(config, pokemon.Species, flags["version"], flags["all"] == "true")
+	if pokemon.Gender != "" {
+		fmt.Println("Gender:", pokemon.Gender)
 	}
-	fmt.Println("Name:", pokemon.Name)
 	fmt.Println("Height:", pokemon.Height)
 	fmt.Println("Weight:", pokemon.Weight)
+	fmt.Println("Level:", pokemon.Level)
+	if err := printExperience(config, pokemon); err != nil {
+		return err
+	}
+	fmt.Printf("HP: %d/%d\n", pokemon.CurrentHP, pokemon.maxHP())
+	if pokemon.Status != capture.StatusNone {
+		fmt.Println("Status:", pokemon.Status)
+	}
+	fmt.Println("Friendship:", pokemon.Friendship)
+	fmt.Println("Ball:", itemName(pokemon.Ball))
+	fmt.Println("Nature:", pokemon.Nature.Name)
+	if pokemon.Ability.Ability.Name != "" {
+		hidden := ""
+		if pokemon.Ability.IsHidden {
+			hidden = " (hidden ability)"
+		}
+		fmt.Println("Ability:", pokemon.Ability.Ability.Name+hidden)
+	}
 	fmt.Println("Stats:")
-
-	for _, stat := range pokemon.Stats {
-		fmt.Println("  -"+stat.Stat.Name+":", stat.BaseStat)
+	for _, name := range statNames {
+		marker := ""
+		switch pokemon.Nature.multiplier(name) {
+		case 1.1:
+			marker = " ↑"
+		case 0.9:
+			marker = " ↓"
+		}
+		fmt.Printf("  -%s: %d (base %d, IV %d, EV %d)%s\n", name, pokemon.stat(name),
+			baseStat(pokemon.Pokemon, name), pokemon.IVs[name], pokemon.EVs[name], marker)
+	}
+	fmt.Println("Moves:")
+	for _, move := range pokemon.KnownMoves {
+		fmt.Println("  -", move)
 	}
 	fmt.Println("Types:")
 	for _, pokemonType := range pokemon.Types {
@@ -182,6 +463,9 @@ func commandInspect(config *Config, args []string) error {
 }
 
 func commandExit(config *Config, args []string) error {
+	if err := saveGame(config); err != nil {
+		fmt.Println(err.Error())
+	}
 	fmt.Println("Closing the Pokedex... Goodbye!")
 	os.Exit(0)
 	return nil
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/battle"
+	"github.com/jthughes/pokedexcli/internal/capture"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// fakeClock is a Clock that never sleeps.
+type fakeClock struct {
+	now   time.Time
+	slept time.Duration
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Sleep(d time.Duration) {
+	clock.slept += d
+}
+
+func testConfig(seed uint64) *Config {
+	return &Config{
+		Pokedex:       map[string]PokedexEntry{},
+		Boxes:         newBoxes(),
+		Bag:           defaultBag(),
+		Settings:      defaultSettings(),
+		Seed:          seed,
+		RNG:           newRNG(seed),
+		SimulationRNG: newSimulationRNG(seed),
+		Clock:         &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+	}
+}
+
+func testWild() *wildPokemon {
+	return newWildPokemon(
+		pokeapi.Pokemon{
+			Name:  "pikachu",
+			Stats: []pokeapi.PokemonStat{{Stat: pokeapi.Resource{Name: "hp"}, BaseStat: 35}},
+		},
+		pokeapi.PokemonSpecies{Name: "pikachu", CaptureRate: 190},
+		10,
+		Stats{"hp": 31},
+		Nature{Name: "hardy", Increased: "attack", Decreased: "attack"},
+	)
+}
+
+func TestThrowBallSeeded(t *testing.T) {
+	expected := []capture.Result{
+		{Shakes: 3, Caught: false},
+		{Shakes: 0, Caught: false},
+		{Shakes: 0, Caught: false},
+		{Shakes: 1, Caught: false},
+		{Shakes: 3, Caught: false},
+		{Shakes: 0, Caught: false},
+		{Shakes: 3, Caught: true},
+		{Shakes: 2, Caught: false},
+	}
+	config := testConfig(42)
+	wild := testWild()
+	for i, want := range expected {
+		got, err := throwBall(config, wild, "poke-ball")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("throw %d: [Expected, Received]: [%+v, %+v]", i, want, got)
+		}
+	}
+}
+
+func TestSimulateDoesNotSleep(t *testing.T) {
+	config := testConfig(7)
+	config.Wild = testWild()
+	err := commandSimulate(config, []string{"simulate", "catch", "pikachu", "great-ball", "--trials", "500"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slept := config.Clock.(*fakeClock).slept; slept != 0 {
+		t.Errorf("expected simulate not to sleep, slept %v", slept)
+	}
+	if config.Bag["great-ball"] != defaultBag()["great-ball"] {
+		t.Errorf("expected simulate not to use balls from the bag")
+	}
+}
+
+func TestCatchOtherSpeciesInBattle(t *testing.T) {
+	config := testConfig(1)
+	config.Settings.FreeCatch = true
+	config.Wild = testWild()
+	engine, err := battle.New([]*battle.Combatant{{Name: "a", HP: 10, MaxHP: 10}},
+		&battle.Combatant{Name: "pikachu", HP: 10, MaxHP: 10}, battle.TypeChart{}, config.RNG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Battle = &battleState{engine: engine, team: []int{0}}
+	if err := commandCatch(config, []string{"catch", "bulbasaur"}); err == nil {
+		t.Errorf("expected an error catching another species in a battle")
+	}
+	if config.Wild.Pokemon.Name != "pikachu" || config.Battle == nil {
+		t.Errorf("expected the battle with pikachu to go on: %+v", config.Wild)
+	}
+	if config.Bag["poke-ball"] != defaultBag()["poke-ball"] {
+		t.Errorf("expected no ball to be thrown")
+	}
+}
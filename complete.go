@@ -0,0 +1,59 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// completions returns the words completing the last word of head, the input
+// before the cursor: command names for the first word, then arguments for
+// the commands that take the name of a Pokemon, an area or a setting.
+func completions(config *Config, head string) []string {
+	words := cleanInput(head)
+	word := ""
+	if len(words) > 0 && !strings.HasSuffix(head, " ") {
+		word = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	candidates := []string{}
+	switch len(words) {
+	case 0:
+		candidates = commandNames()
+	case 1:
+		candidates = argumentCompletions(config, words[0])
+	case 2:
+		if words[0] == "catch" {
+			candidates = slices.Collect(maps.Keys(config.Bag))
+		}
+	}
+	matches := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), word) {
+			matches = append(matches, strings.ToLower(candidate))
+		}
+	}
+	slices.Sort(matches)
+	return slices.Compact(matches)
+}
+
+// argumentCompletions returns the candidates for a command's first argument.
+func argumentCompletions(config *Config, command string) []string {
+	switch command {
+	case "catch":
+		candidates := []string{}
+		if config.Wild != nil {
+			candidates = append(candidates, config.Wild.displayName(config))
+		}
+		species, _ := nameIndex(config, "pokemon-species")
+		return append(candidates, species...)
+	case "explore", "travel":
+		areas, _ := nameIndex(config, "location-area")
+		return areas
+	case "inspect", "deposit", "withdraw", "switch", "swap", "evolve":
+		return append(ownedNames(config), slices.Collect(maps.Keys(config.Pokedex))...)
+	case "set":
+		return slices.Collect(maps.Keys(registerSettings()))
+	}
+	return nil
+}
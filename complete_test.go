@@ -0,0 +1,40 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestCompletions(t *testing.T) {
+	commands = registerCommands()
+	config := testConfig(1)
+	config.NameIndex = map[string][]string{
+		"pokemon-species": {"pidgey", "pikachu", "bulbasaur"},
+		"location-area":   {"canalave-city-area", "eterna-forest-area"},
+	}
+	config.Party = []Pokemon{
+		{Pokemon: pokeapi.Pokemon{Name: "pikachu"}},
+		{Pokemon: pokeapi.Pokemon{Name: "psyduck"}},
+	}
+	config.Bag = Bag{"poke-ball": 5, "great-ball": 2, "ultra-ball": 1}
+	config.Pokedex["pidgey"] = PokedexEntry{Caught: 1}
+	cases := []struct {
+		head     string
+		expected []string
+	}{
+		{head: "ma", expected: []string{"map", "mapb", "matchup"}},
+		{head: "catch pi", expected: []string{"pidgey", "pikachu"}},
+		{head: "catch pikachu ", expected: []string{"great-ball", "poke-ball", "ultra-ball"}},
+		{head: "explore e", expected: []string{"eterna-forest-area"}},
+		{head: "inspect p", expected: []string{"pidgey", "pikachu", "psyduck"}},
+		{head: "set shiny", expected: []string{"shiny-charm", "shiny-odds"}},
+		{head: "bag x", expected: []string{}},
+	}
+	for _, c := range cases {
+		if actual := completions(config, c.head); !slices.Equal(actual, c.expected) {
+			t.Errorf("%q: [Expected, Received]: [%v, %v]", c.head, c.expected, actual)
+		}
+	}
+}
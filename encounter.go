@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/jthughes/pokedexcli/internal/battle"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// maxSteps is how far the trainer walks looking for a wild Pokemon before
+// giving up.
+const maxSteps = 20
+
+type encounterSlot struct {
+	pokemon string
+	detail  pokeapi.Encounter
+}
+
+// encounterVersion returns the game version whose encounter table is used for
+// area: the configured version if the area has one for it, otherwise the
+// first version listed.
+func encounterVersion(area pokeapi.LocationArea, preferred string) string {
+	first := ""
+	for _, encounter := range area.Encounters {
+		for _, details := range encounter.VersionDetails {
+			if details.Version.Name == preferred {
+				return preferred
+			}
+			if first == "" {
+				first = details.Version.Name
+			}
+		}
+	}
+	return first
+}
+
+// encounterSlots returns the area's encounters for version and method.
+func encounterSlots(area pokeapi.LocationArea, version, method string) []encounterSlot {
+	slots := []encounterSlot{}
+	for _, encounter := range area.Encounters {
+		for _, details := range encounter.VersionDetails {
+			if details.Version.Name != version {
+				continue
+			}
+			for _, detail := range details.EncounterDetails {
+				if detail.Method.Name == method {
+					slots = append(slots, encounterSlot{pokemon: encounter.Pokemon.Name, detail: detail})
+				}
+			}
+		}
+	}
+	return slots
+}
+
+// encounterMethods returns the methods with encounters in area for version.
+func encounterMethods(area pokeapi.LocationArea, version string) []string {
+	methods := []string{}
+	for _, encounter := range area.Encounters {
+		for _, details := range encounter.VersionDetails {
+			if details.Version.Name != version {
+				continue
+			}
+			for _, detail := range details.EncounterDetails {
+				if !slices.Contains(methods, detail.Method.Name) {
+					methods = append(methods, detail.Method.Name)
+				}
+			}
+		}
+	}
+	slices.Sort(methods)
+	return methods
+}
+
+// encounterRate returns the chance out of 100 of meeting a Pokemon on each
+// step using method, or 100 if the area doesn't say.
+func encounterRate(area pokeapi.LocationArea, version, method string) int {
+	for _, rates := range area.EncounterMethodRates {
+		if rates.Method.Name != method {
+			continue
+		}
+		for _, details := range rates.VersionDetails {
+			if details.Version.Name == version && details.Rate > 0 {
+				return details.Rate
+			}
+		}
+	}
+	return 100
+}
+
+// rollEncounter walks up to maxSteps steps, rolling against rate on each. When
+// a Pokemon appears it is picked from slots weighted by chance, with a level
+// between the slot's minimum and maximum.
+func rollEncounter(slots []encounterSlot, rate int, rng *rand.Rand) (slot encounterSlot, level int, steps int, ok bool) {
+	total := 0
+	for _, slot := range slots {
+		total += slot.detail.Chance
+	}
+	if total <= 0 {
+		return encounterSlot{}, 0, 0, false
+	}
+	for steps = 1; steps <= maxSteps; steps++ {
+		if rng.IntN(100) < rate {
+			break
+		}
+	}
+	if steps > maxSteps {
+		return encounterSlot{}, 0, maxSteps, false
+	}
+	roll := rng.IntN(total)
+	for _, slot = range slots {
+		roll -= slot.detail.Chance
+		if roll < 0 {
+			break
+		}
+	}
+	minLevel := max(slot.detail.MinLevel, 1)
+	maxLevel := max(slot.detail.MaxLevel, minLevel)
+	level = minLevel + rng.IntN(maxLevel-minLevel+1)
+	return slot, level, steps, true
+}
+
+func commandEncounter(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:], "method")
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usage("encounter [--method <method>]")
+	}
+	method := "walk"
+	if value, ok := flags["method"]; ok {
+		method = value
+	}
+	return encounter(config, method)
+}
+
+func commandWalk(config *Config, args []string) error {
+	if len(args) != 1 {
+		return usage("walk")
+	}
+	return encounter(config, "walk")
+}
+
+// encounter looks for a wild Pokemon in the trainer's current area.
+func encounter(config *Config, method string) error {
+	if config.Wild != nil {
+		return errors.New("You are already facing a wild " + config.Wild.displayName(config) + "! Catch it or run.")
+	}
+	areaName, err := currentArea(config, nil)
+	if err != nil {
+		return err
+	}
+	area, err := pokeapi.GetLocationArea(areaName, config.Cache)
+	if err != nil {
+		return err
+	}
+	version := encounterVersion(area, config.Settings.Version)
+	slots := encounterSlots(area, version, method)
+	if len(slots) == 0 {
+		message := "No Pokemon can be found in " + areaName + " by " + method + "."
+		if methods := encounterMethods(area, version); len(methods) > 0 {
+			message += "\nTry one of: " + strings.Join(methods, ", ")
+		}
+		return errors.New(message)
+	}
+
+	slot, level, steps, ok := rollEncounter(slots, encounterRate(area, version, method), config.RNG)
+	if !ok {
+		fmt.Printf("You searched for %d steps, but nothing appeared.\n", steps)
+		return nil
+	}
+	pokemon, err := pokeapi.GetPokemon(slot.pokemon, config.Cache)
+	if err != nil {
+		return err
+	}
+	pokemonSpecies, err := pokeapi.GetPokemonSpecies(pokemon.Species.Name, config.Cache)
+	if err != nil {
+		return err
+	}
+	config.Wild, err = spawnWild(config, config.RNG, pokemon, pokemonSpecies, level)
+	if err != nil {
+		return err
+	}
+	config.Wild.Method = method
+	fmt.Printf("After %d steps, a wild %s appeared!\n", steps, config.Wild.describe(config))
+	return nil
+}
+
+func commandRun(config *Config, args []string) error {
+	if len(args) != 1 {
+		return usage("run")
+	}
+	if config.Wild == nil {
+		return errors.New("There is nothing to run from.")
+	}
+	if config.Battle != nil {
+		return battleTurn(config, battle.Action{Kind: battle.Run})
+	}
+	fmt.Println("Got away safely from the wild " + config.Wild.displayName(config) + "!")
+	config.Wild = nil
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func testArea() pokeapi.LocationArea {
+	detail := func(method string, chance, minLevel, maxLevel int) pokeapi.Encounter {
+		return pokeapi.Encounter{
+			Chance:   chance,
+			Method:   pokeapi.Resource{Name: method},
+			MinLevel: minLevel,
+			MaxLevel: maxLevel,
+		}
+	}
+	return pokeapi.LocationArea{
+		Encounters: []pokeapi.PokemonEncounter{
+			{
+				Pokemon: pokeapi.Resource{Name: "pidgey"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{
+						Version:          pokeapi.Resource{Name: "red"},
+						EncounterDetails: []pokeapi.Encounter{detail("walk", 60, 2, 5)},
+					},
+				},
+			},
+			{
+				Pokemon: pokeapi.Resource{Name: "rattata"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{
+						Version:          pokeapi.Resource{Name: "red"},
+						EncounterDetails: []pokeapi.Encounter{detail("walk", 40, 2, 4)},
+					},
+					{
+						Version:          pokeapi.Resource{Name: "blue"},
+						EncounterDetails: []pokeapi.Encounter{detail("walk", 100, 3, 3)},
+					},
+				},
+			},
+			{
+				Pokemon: pokeapi.Resource{Name: "magikarp"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{
+						Version:          pokeapi.Resource{Name: "red"},
+						EncounterDetails: []pokeapi.Encounter{detail("old-rod", 100, 5, 5)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEncounterSlots(t *testing.T) {
+	area := testArea()
+	if version := encounterVersion(area, ""); version != "red" {
+		t.Errorf("[Expected, Received]: ['red', '%s']", version)
+	}
+	if version := encounterVersion(area, "blue"); version != "blue" {
+		t.Errorf("[Expected, Received]: ['blue', '%s']", version)
+	}
+	cases := []struct {
+		version  string
+		method   string
+		expected int
+	}{
+		{version: "red", method: "walk", expected: 2},
+		{version: "red", method: "old-rod", expected: 1},
+		{version: "blue", method: "walk", expected: 1},
+		{version: "blue", method: "surf", expected: 0},
+	}
+	for _, c := range cases {
+		if slots := encounterSlots(area, c.version, c.method); len(slots) != c.expected {
+			t.Errorf("%s %s: [Expected, Received]: [%d, %d]", c.version, c.method, c.expected, len(slots))
+		}
+	}
+}
+
+func TestRollEncounter(t *testing.T) {
+	slots := encounterSlots(testArea(), "red", "walk")
+	rng := newRNG(1)
+	counts := map[string]int{}
+	for range 10_000 {
+		slot, level, _, ok := rollEncounter(slots, 100, rng)
+		if !ok {
+			t.Fatal("expected an encounter at a 100% rate")
+		}
+		if level < slot.detail.MinLevel || level > slot.detail.MaxLevel {
+			t.Errorf("%s: level %d outside [%d, %d]", slot.pokemon, level, slot.detail.MinLevel, slot.detail.MaxLevel)
+		}
+		counts[slot.pokemon] += 1
+	}
+	if counts["pidgey"] < 5_700 || counts["pidgey"] > 6_300 {
+		t.Errorf("expected pidgey about 60%% of the time, got %d of 10000", counts["pidgey"])
+	}
+
+	if _, _, steps, ok := rollEncounter(slots, 0, rng); ok || steps != maxSteps {
+		t.Errorf("expected no encounter at a 0%% rate")
+	}
+}
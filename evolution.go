@@ -0,0 +1,341 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// timeOfDay returns "day", "dusk" or "night", as used by evolution conditions.
+func timeOfDay(now time.Time) string {
+	switch hour := now.Hour(); {
+	case hour >= 20 || hour < 4:
+		return "night"
+	case hour >= 17:
+		return "dusk"
+	default:
+		return "day"
+	}
+}
+
+// evolutionGenders maps the gender IDs used by evolution conditions to
+// genders.
+var evolutionGenders = map[int]string{1: "female", 2: "male"}
+
+// evolutionContext is everything an owned Pokemon's evolution can depend on.
+type evolutionContext struct {
+	// trigger is how evolution is attempted: "level-up", "use-item" or
+	// "trade".
+	trigger string
+	// item is the item used, or held while being traded.
+	item           string
+	gender         string
+	level          int
+	friendship     int
+	knownMoves     []string
+	knownMoveTypes []string
+	location       string
+	now            time.Time
+	partySpecies   []string
+	partyTypes     []string
+	attack         int
+	defense        int
+}
+
+// evolutionMet reports whether every condition of detail is met.
+func evolutionMet(detail pokeapi.EvolutionDetail, c evolutionContext) bool {
+	// Conditions on things the game doesn't model can never be met.
+	if detail.MinBeauty != nil || detail.MinAffection != nil ||
+		detail.NeedsOverworldRain || detail.TradeSpecies != nil || detail.TurnUpsideDown {
+		return false
+	}
+	has := func(resource *pokeapi.Resource, names []string) bool {
+		return resource == nil || slices.Contains(names, resource.Name)
+	}
+	return detail.Trigger.Name == c.trigger &&
+		has(detail.Item, []string{c.item}) &&
+		has(detail.HeldItem, []string{c.item}) &&
+		has(detail.KnownMove, c.knownMoves) &&
+		has(detail.KnownMoveType, c.knownMoveTypes) &&
+		has(detail.Location, []string{c.location}) &&
+		has(detail.PartySpecies, c.partySpecies) &&
+		has(detail.PartyType, c.partyTypes) &&
+		(detail.Gender == nil || evolutionGenders[*detail.Gender] == c.gender) &&
+		(detail.MinLevel == nil || c.level >= *detail.MinLevel) &&
+		(detail.MinHappiness == nil || c.friendship >= *detail.MinHappiness) &&
+		(detail.RelativePhysicalStats == nil || cmp.Compare(c.attack, c.defense) == *detail.RelativePhysicalStats) &&
+		(detail.TimeOfDay == "" || detail.TimeOfDay == timeOfDay(c.now))
+}
+
+// describeEvolution summarizes the conditions of detail, such as "level 16"
+// or "use water-stone".
+func describeEvolution(detail pokeapi.EvolutionDetail) string {
+	parts := []string{}
+	switch detail.Trigger.Name {
+	case "level-up":
+		if detail.MinLevel != nil {
+			parts = append(parts, "level "+strconv.Itoa(*detail.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if detail.Item != nil {
+			parts = append(parts, "use "+detail.Item.Name)
+		}
+	default:
+		parts = append(parts, detail.Trigger.Name)
+	}
+	if detail.HeldItem != nil {
+		parts = append(parts, "holding "+detail.HeldItem.Name)
+	}
+	if detail.MinHappiness != nil {
+		parts = append(parts, "friendship "+strconv.Itoa(*detail.MinHappiness)+"+")
+	}
+	if detail.MinBeauty != nil {
+		parts = append(parts, "beauty "+strconv.Itoa(*detail.MinBeauty)+"+")
+	}
+	if detail.MinAffection != nil {
+		parts = append(parts, "affection "+strconv.Itoa(*detail.MinAffection)+"+")
+	}
+	if detail.KnownMove != nil {
+		parts = append(parts, "knowing "+detail.KnownMove.Name)
+	}
+	if detail.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+detail.KnownMoveType.Name+" move")
+	}
+	if detail.Location != nil {
+		parts = append(parts, "at "+detail.Location.Name)
+	}
+	if detail.TimeOfDay != "" {
+		parts = append(parts, map[string]string{
+			"day": "during the day", "dusk": "at dusk", "night": "at night",
+		}[detail.TimeOfDay])
+	}
+	if detail.Gender != nil {
+		parts = append(parts, evolutionGenders[*detail.Gender])
+	}
+	if detail.PartySpecies != nil {
+		parts = append(parts, "with "+detail.PartySpecies.Name+" in the party")
+	}
+	if detail.PartyType != nil {
+		parts = append(parts, "with a "+detail.PartyType.Name+" type in the party")
+	}
+	if detail.RelativePhysicalStats != nil {
+		parts = append(parts, map[int]string{
+			1: "attack > defense", 0: "attack = defense", -1: "attack < defense",
+		}[*detail.RelativePhysicalStats])
+	}
+	if detail.NeedsOverworldRain {
+		parts = append(parts, "in the rain")
+	}
+	if detail.TradeSpecies != nil {
+		parts = append(parts, "for "+detail.TradeSpecies.Name)
+	}
+	if detail.TurnUpsideDown {
+		parts = append(parts, "upside down")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeEvolutions joins the ways of evolving into a species.
+func describeEvolutions(details []pokeapi.EvolutionDetail) string {
+	descriptions := []string{}
+	for _, detail := range details {
+		if description := describeEvolution(detail); !slices.Contains(descriptions, description) {
+			descriptions = append(descriptions, description)
+		}
+	}
+	return strings.Join(descriptions, " or ")
+}
+
+// printChain renders link and the species it evolves into as a tree.
+func printChain(config *Config, link pokeapi.ChainLink, indent string) {
+	for i, next := range link.EvolvesTo {
+		branch, child := "├── ", "│   "
+		if i == len(link.EvolvesTo)-1 {
+			branch, child = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s (%s)\n", indent, branch, localSpeciesName(config, next.Species.Name),
+			describeEvolutions(next.EvolutionDetails))
+		printChain(config, next, indent+child)
+	}
+}
+
+func commandEvolution(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("evolution <pokemon>")
+	}
+	species, err := pokeapi.GetPokemonSpecies(args[1], config.Cache)
+	if err != nil {
+		return err
+	}
+	chain, err := pokeapi.GetEvolutionChain(species.EvolutionChain.Url, config.Cache)
+	if err != nil {
+		return err
+	}
+	fmt.Println(localSpeciesName(config, chain.Chain.Species.Name))
+	printChain(config, chain.Chain, "")
+	return nil
+}
+
+// newEvolutionContext gathers what pokemon's evolution can depend on when
+// attempted by trigger, using item.
+func newEvolutionContext(config *Config, pokemon *Pokemon, trigger string, item string) (evolutionContext, error) {
+	context := evolutionContext{
+		trigger:    trigger,
+		item:       item,
+		gender:     pokemon.Gender,
+		level:      pokemon.Level,
+		friendship: pokemon.Friendship,
+		knownMoves: pokemon.KnownMoves,
+		now:        config.Clock.Now(),
+		attack:     pokemon.stat("attack"),
+		defense:    pokemon.stat("defense"),
+	}
+	for _, name := range pokemon.KnownMoves {
+		move, err := pokeapi.GetMove(name, config.Cache)
+		if err != nil {
+			return evolutionContext{}, err
+		}
+		context.knownMoveTypes = append(context.knownMoveTypes, move.Type.Name)
+	}
+	if config.Location != "" {
+		area, err := pokeapi.GetLocationArea(config.Location, config.Cache)
+		if err != nil {
+			return evolutionContext{}, err
+		}
+		context.location = area.Location.Name
+	}
+	for i, member := range config.Party {
+		if &config.Party[i] == pokemon {
+			continue
+		}
+		context.partySpecies = append(context.partySpecies, member.Species.Name)
+		context.partyTypes = append(context.partyTypes, pokemonTypes(member.Pokemon, config.Settings.Generation)...)
+	}
+	return context, nil
+}
+
+// findEvolution returns the species pokemon can evolve into when attempted
+// by trigger using item, and the condition it meets, or an empty name if it
+// can't evolve.
+func findEvolution(config *Config, pokemon *Pokemon, trigger string, item string) (string, pokeapi.EvolutionDetail, error) {
+	if pokemon.Species.EvolutionChain.Url == "" {
+		return "", pokeapi.EvolutionDetail{}, nil
+	}
+	chain, err := pokeapi.GetEvolutionChain(pokemon.Species.EvolutionChain.Url, config.Cache)
+	if err != nil {
+		return "", pokeapi.EvolutionDetail{}, err
+	}
+	link := chain.Chain.Find(pokemon.Species.Name)
+	if link == nil || len(link.EvolvesTo) == 0 {
+		return "", pokeapi.EvolutionDetail{}, nil
+	}
+	context, err := newEvolutionContext(config, pokemon, trigger, item)
+	if err != nil {
+		return "", pokeapi.EvolutionDetail{}, err
+	}
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if evolutionMet(detail, context) {
+				return next.Species.Name, detail, nil
+			}
+		}
+	}
+	return "", pokeapi.EvolutionDetail{}, nil
+}
+
+// evolve turns pokemon into the species named into, keeping everything that
+// makes it an individual.
+func evolve(config *Config, pokemon *Pokemon, into string) error {
+	species, err := pokeapi.GetPokemonSpecies(into, config.Cache)
+	if err != nil {
+		return err
+	}
+	pokemonName := species.Name
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			pokemonName = variety.Pokemon.Name
+		}
+	}
+	evolved, err := pokeapi.GetPokemon(pokemonName, config.Cache)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("What? %s is evolving!\n", pokemon.displayName(config))
+	config.Clock.Sleep(1500 * time.Millisecond)
+	oldName, oldMaxHP := pokemon.displayName(config), pokemon.maxHP()
+	pokemon.Pokemon = evolved
+	pokemon.Species = species
+	if pokemon.Ability.Ability.Name != "" {
+		pokemon.Ability = abilityInSlot(evolved, pokemon.Ability.Slot)
+	}
+	pokemon.CurrentHP += pokemon.maxHP() - oldMaxHP
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", oldName, pokemon.displayName(config))
+	// Evolving registers the species as owned, without counting a catch.
+	if _, ok := config.Pokedex[species.Name]; !ok {
+		fmt.Println("Adding " + pokemon.displayName(config) + " to the Pokedex.")
+		config.Pokedex[species.Name] = PokedexEntry{}
+	}
+	return nil
+}
+
+// evolveOnLevelUp evolves pokemon if it now meets the conditions for
+// evolving by levelling up.
+func evolveOnLevelUp(config *Config, pokemon *Pokemon) error {
+	into, _, err := findEvolution(config, pokemon, "level-up", "")
+	if err != nil || into == "" {
+		return err
+	}
+	return evolve(config, pokemon, into)
+}
+
+func commandEvolve(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:], "item")
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usage("evolve <pokemon> [--item <item>] [--trade]")
+	}
+	if config.Battle != nil {
+		return errors.New("You can't do that in the middle of a battle!")
+	}
+	pokemon := findPokemon(config, positional[0])
+	if pokemon == nil {
+		return suggest("You don't have "+positional[0]+" in your party or the PC.", positional[0], ownedNames(config))
+	}
+	item := flags["item"]
+	if item != "" && config.Bag[item] <= 0 {
+		return suggest("You don't have any "+itemName(item)+".", item, slices.Collect(maps.Keys(config.Bag)))
+	}
+	trigger := "use-item"
+	if flags["trade"] == "true" {
+		trigger = "trade"
+	} else if item == "" {
+		return usage("evolve <pokemon> [--item <item>] [--trade]")
+	}
+	into, detail, err := findEvolution(config, pokemon, trigger, item)
+	if err != nil {
+		return err
+	}
+	if into == "" {
+		if trigger == "use-item" {
+			fmt.Println("It won't have any effect.")
+		} else {
+			fmt.Println(pokemon.displayName(config) + " didn't evolve.")
+		}
+		return nil
+	}
+	if detail.Item != nil || detail.HeldItem != nil {
+		config.Bag.take(item)
+	}
+	return evolve(config, pokemon, into)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+	"github.com/jthughes/pokedexcli/internal/pokecache"
+)
+
+func TestEvolutionMet(t *testing.T) {
+	level := func(n int) *int { return &n }
+	resource := func(name string) *pokeapi.Resource { return &pokeapi.Resource{Name: name} }
+	noon := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		detail   pokeapi.EvolutionDetail
+		context  evolutionContext
+		expected bool
+	}{
+		{
+			name:     "level reached",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "level-up"}, MinLevel: level(16)},
+			context:  evolutionContext{trigger: "level-up", level: 16},
+			expected: true,
+		},
+		{
+			name:     "level not reached",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "level-up"}, MinLevel: level(16)},
+			context:  evolutionContext{trigger: "level-up", level: 15},
+			expected: false,
+		},
+		{
+			name:     "stone",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "use-item"}, Item: resource("water-stone")},
+			context:  evolutionContext{trigger: "use-item", item: "water-stone"},
+			expected: true,
+		},
+		{
+			name:     "wrong stone",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "use-item"}, Item: resource("water-stone")},
+			context:  evolutionContext{trigger: "use-item", item: "fire-stone"},
+			expected: false,
+		},
+		{
+			name: "friendship at night",
+			detail: pokeapi.EvolutionDetail{
+				Trigger: pokeapi.Resource{Name: "level-up"}, MinHappiness: level(160), TimeOfDay: "night",
+			},
+			context:  evolutionContext{trigger: "level-up", friendship: 200, now: midnight},
+			expected: true,
+		},
+		{
+			name: "friendship during the day",
+			detail: pokeapi.EvolutionDetail{
+				Trigger: pokeapi.Resource{Name: "level-up"}, MinHappiness: level(160), TimeOfDay: "night",
+			},
+			context:  evolutionContext{trigger: "level-up", friendship: 200, now: noon},
+			expected: false,
+		},
+		{
+			name:     "trade holding an item",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "trade"}, HeldItem: resource("metal-coat")},
+			context:  evolutionContext{trigger: "trade", item: "metal-coat"},
+			expected: true,
+		},
+		{
+			name:     "known move",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "level-up"}, KnownMove: resource("mimic")},
+			context:  evolutionContext{trigger: "level-up", knownMoves: []string{"confusion", "mimic"}},
+			expected: true,
+		},
+		{
+			name:     "higher attack",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "level-up"}, MinLevel: level(20), RelativePhysicalStats: level(1)},
+			context:  evolutionContext{trigger: "level-up", level: 20, attack: 30, defense: 25},
+			expected: true,
+		},
+		{
+			name:     "female only",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "use-item"}, Item: resource("dawn-stone"), Gender: level(1)},
+			context:  evolutionContext{trigger: "use-item", item: "dawn-stone", gender: "male"},
+			expected: false,
+		},
+		{
+			name:     "unmodelled condition",
+			detail:   pokeapi.EvolutionDetail{Trigger: pokeapi.Resource{Name: "level-up"}, NeedsOverworldRain: true},
+			context:  evolutionContext{trigger: "level-up", level: 50},
+			expected: false,
+		},
+	}
+	for _, c := range cases {
+		if actual := evolutionMet(c.detail, c.context); actual != c.expected {
+			t.Errorf("%s: [Expected, Received]: [%v, %v]", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestDescribeEvolution(t *testing.T) {
+	happiness := 220
+	detail := pokeapi.EvolutionDetail{
+		Trigger:      pokeapi.Resource{Name: "level-up"},
+		MinHappiness: &happiness,
+		TimeOfDay:    "day",
+	}
+	expected := "level up, friendship 220+, during the day"
+	if actual := describeEvolution(detail); actual != expected {
+		t.Errorf("[Expected, Received]: ['%s', '%s']", expected, actual)
+	}
+}
+
+func TestEvolveRegistersWithoutCatch(t *testing.T) {
+	config := testConfig(1)
+	config.Cache = pokecache.NewCache(time.Minute)
+	cachePokemon(config.Cache, "raichu", 75)
+	config.Pokedex["pikachu"] = PokedexEntry{Caught: 1}
+	pikachu := Pokemon{Pokemon: pokeapi.Pokemon{Name: "pikachu"}, Level: 20}
+	if err := evolve(config, &pikachu, "raichu"); err != nil {
+		t.Fatal(err)
+	}
+	if pikachu.Name != "raichu" {
+		t.Errorf("[Expected, Received]: [%q, %q]", "raichu", pikachu.Name)
+	}
+	entry, ok := config.Pokedex["raichu"]
+	if !ok || entry.Caught != 0 {
+		t.Errorf("expected raichu to be owned without being caught: %+v", config.Pokedex)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+const maxLevel = 100
+
+// experienceYield returns the experience gained for defeating or catching a
+// wild Pokemon, using the main series formula b * L / 7.
+func experienceYield(pokemon pokeapi.Pokemon, level int) int {
+	return max(pokemon.BaseExperience*level/7, 1)
+}
+
+// friendshipForLevelUp returns the friendship gained on levelling up, which
+// shrinks as friendship grows.
+func friendshipForLevelUp(friendship int) int {
+	switch {
+	case friendship < 100:
+		return 5
+	case friendship < 200:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// gainExperience adds experience to pokemon, levelling it up as its growth
+// rate dictates.
+func gainExperience(config *Config, pokemon *Pokemon, experience int) error {
+	if pokemon.Level >= maxLevel {
+		return nil
+	}
+	rate, err := pokeapi.GetGrowthRate(pokemon.Species.GrowthRate.Name, config.Cache)
+	if err != nil {
+		return err
+	}
+	pokemon.Experience = min(pokemon.Experience+experience, rate.Experience(maxLevel))
+	fmt.Printf("%s gained %d Exp. Points!\n", pokemon.displayName(config), experience)
+	oldLevel, oldMaxHP := pokemon.Level, pokemon.maxHP()
+	for level := rate.Level(pokemon.Experience); pokemon.Level < level; {
+		pokemon.Level += 1
+		pokemon.addFriendship(friendshipForLevelUp(pokemon.Friendship))
+		fmt.Printf("%s grew to level %d!\n", pokemon.displayName(config), pokemon.Level)
+	}
+	pokemon.CurrentHP += pokemon.maxHP() - oldMaxHP
+	if pokemon.Level > oldLevel {
+		return evolveOnLevelUp(config, pokemon)
+	}
+	return nil
+}
+
+// yieldRecipients returns the party indices of the Pokemon earning the yield
+// for defeating or catching the wild Pokemon: those sent out in the battle
+// that haven't fainted, or, outside a battle, the first able to battle.
+func yieldRecipients(config *Config) []int {
+	if config.Battle == nil {
+		team := battleTeam(config)
+		return team[:min(len(team), 1)]
+	}
+	state := config.Battle
+	participants := state.engine.Participants()
+	recipients := []int{}
+	for i, index := range state.team {
+		if slices.Contains(participants, state.engine.Team[i]) && config.Party[index].CurrentHP > 0 {
+			recipients = append(recipients, index)
+		}
+	}
+	return recipients
+}
+
+// awardYield gives the effort values for defeating or catching wild to each
+// recipient, a party index, and splits the experience between them.
+func awardYield(config *Config, wild *wildPokemon, recipients []int) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	experience := max(experienceYield(wild.Pokemon, wild.Level)/len(recipients), 1)
+	for _, index := range recipients {
+		pokemon := &config.Party[index]
+		if pokemon.EVs == nil {
+			pokemon.EVs = Stats{}
+		}
+		oldMaxHP := pokemon.maxHP()
+		gainEffort(pokemon.EVs, wild.Pokemon.Stats)
+		pokemon.CurrentHP += pokemon.maxHP() - oldMaxHP
+		if err := gainExperience(config, pokemon, experience); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leadPokemon returns the first Pokemon in the trainer's party, or nil if the
+// party is empty.
+func leadPokemon(config *Config) *Pokemon {
+	if len(config.Party) == 0 {
+		return nil
+	}
+	return &config.Party[0]
+}
+
+func printExperience(config *Config, pokemon Pokemon) error {
+	fmt.Println("Experience:", pokemon.Experience)
+	if pokemon.Level >= maxLevel {
+		return nil
+	}
+	rate, err := pokeapi.GetGrowthRate(pokemon.Species.GrowthRate.Name, config.Cache)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  %d to level %d (%s growth)\n",
+		rate.Experience(pokemon.Level+1)-pokemon.Experience, pokemon.Level+1, rate.Name)
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/battle"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+	"github.com/jthughes/pokedexcli/internal/pokecache"
+)
+
+func TestYieldRecipients(t *testing.T) {
+	config := testConfig(1)
+	config.Party = []Pokemon{{CurrentHP: 0}, {CurrentHP: 10}, {CurrentHP: 10}}
+	if actual := yieldRecipients(config); !slices.Equal(actual, []int{1}) {
+		t.Errorf("[Expected, Received]: [%v, %v]", []int{1}, actual)
+	}
+
+	team := []*battle.Combatant{{Name: "a", HP: 10, MaxHP: 10}, {Name: "b", HP: 10, MaxHP: 10}}
+	engine, err := battle.New(team, &battle.Combatant{Name: "wild", HP: 10, MaxHP: 10}, battle.TypeChart{}, config.RNG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Battle = &battleState{engine: engine, team: []int{1, 2}}
+	if actual := yieldRecipients(config); !slices.Equal(actual, []int{1}) {
+		t.Errorf("[Expected, Received]: [%v, %v]", []int{1}, actual)
+	}
+}
+
+func TestAwardYieldSplitsExperience(t *testing.T) {
+	config := testConfig(1)
+	config.Cache = pokecache.NewCache(time.Minute)
+	config.Cache.Add("https://pokeapi.co/api/v2/growth-rate/medium",
+		[]byte(`{"name": "medium", "levels": [{"level": 1, "experience": 0}, {"level": 100, "experience": 1000000}]}`))
+	species := pokeapi.PokemonSpecies{GrowthRate: pokeapi.Resource{Name: "medium"}}
+	config.Party = []Pokemon{
+		{Species: species, Level: 1, CurrentHP: 10},
+		{Species: species, Level: 1, CurrentHP: 10},
+		{Species: species, Level: 1, CurrentHP: 10},
+	}
+	wild := testWild()
+	wild.Pokemon.BaseExperience = 70
+	if err := awardYield(config, wild, []int{0, 2}); err != nil {
+		t.Fatal(err)
+	}
+	// Level 10, base 70: 100 experience, split two ways.
+	expected := []int{50, 0, 50}
+	for i, pokemon := range config.Party {
+		if pokemon.Experience != expected[i] {
+			t.Errorf("party %d: [Expected, Received]: [%d, %d]", i, expected[i], pokemon.Experience)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// textLanguage returns the language to show descriptions in. Descriptions
+// have no API slug to fall back to, so English is used by default.
+func textLanguage(config *Config) string {
+	if config.Settings.Language == "" {
+		return "en"
+	}
+	return config.Settings.Language
+}
+
+// normalizeFlavorText tidies Pokedex entry text, which keeps the line and
+// page breaks and soft hyphens of the games' text boxes.
+func normalizeFlavorText(text string) string {
+	text = strings.NewReplacer("\u00ad\n", "", "\u00ad", "", "\f", " ", "\n", " ", "POKéMON", "Pokémon").Replace(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// genus returns the species' category, such as "Seed Pokémon".
+func genus(config *Config, species pokeapi.PokemonSpecies) string {
+	for _, genus := range species.Genera {
+		if strings.EqualFold(genus.Language.Name, textLanguage(config)) {
+			return genus.Genus
+		}
+	}
+	return ""
+}
+
+type flavorText struct {
+	versions []string
+	text     string
+}
+
+// flavorTexts returns the species' distinct Pokedex entries in the
+// configured language, each with the versions it appears in, oldest first.
+func flavorTexts(config *Config, species pokeapi.PokemonSpecies) []flavorText {
+	texts := []flavorText{}
+	for _, entry := range species.FlavorTextEntries {
+		if !strings.EqualFold(entry.Language.Name, textLanguage(config)) {
+			continue
+		}
+		text := normalizeFlavorText(entry.FlavorText)
+		found := false
+		for i := range texts {
+			if texts[i].text == text {
+				texts[i].versions = append(texts[i].versions, entry.Version.Name)
+				found = true
+			}
+		}
+		if !found {
+			texts = append(texts, flavorText{versions: []string{entry.Version.Name}, text: text})
+		}
+	}
+	return texts
+}
+
+// printFlavorText prints the species' Pokedex entry for version, its latest
+// entry if version is empty, or every entry if all is set.
+func printFlavorText(config *Config, species pokeapi.PokemonSpecies, version string, all bool) {
+	texts := flavorTexts(config, species)
+	if len(texts) == 0 {
+		return
+	}
+	if all {
+		fmt.Println("Pokedex entries:")
+		for _, text := range texts {
+			fmt.Printf("  %s: %s\n", strings.Join(text.versions, ", "), text.text)
+		}
+		return
+	}
+	if version == "" {
+		fmt.Println("Pokedex entry:", texts[len(texts)-1].text)
+		return
+	}
+	for _, text := range texts {
+		for _, textVersion := range text.versions {
+			if textVersion == version {
+				fmt.Println("Pokedex entry:", text.text)
+				return
+			}
+		}
+	}
+	fmt.Println("There is no Pokedex entry for " + version + ".")
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestNormalizeFlavorText(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "A strange seed was\nplanted on its\nback at birth.\fThe plant sprouts\nand grows with\nthis POKéMON.",
+			expected: "A strange seed was planted on its back at birth. The plant sprouts and grows with this Pokémon.",
+		},
+		{
+			input:    "It stores elec\u00ad\ntricity in its\u00ad cheeks.",
+			expected: "It stores electricity in its cheeks.",
+		},
+	}
+	for _, c := range cases {
+		if actual := normalizeFlavorText(c.input); actual != c.expected {
+			t.Errorf("[Expected, Received]: [%q, %q]", c.expected, actual)
+		}
+	}
+}
+
+func TestFlavorTexts(t *testing.T) {
+	species := pokeapi.PokemonSpecies{}
+	for _, entry := range []struct{ text, language, version string }{
+		{"It stores\nelectricity.", "en", "red"},
+		{"It stores electricity.", "en", "blue"},
+		{"Elle stocke l'électricité.", "fr", "x"},
+		{"Its cheeks crackle.", "en", "x"},
+	} {
+		species.FlavorTextEntries = append(species.FlavorTextEntries, struct {
+			FlavorText string           `json:"flavor_text"`
+			Language   pokeapi.Resource `json:"language"`
+			Version    pokeapi.Resource `json:"version"`
+		}{entry.text, pokeapi.Resource{Name: entry.language}, pokeapi.Resource{Name: entry.version}})
+	}
+	config := testConfig(1)
+	texts := flavorTexts(config, species)
+	if len(texts) != 2 || len(texts[0].versions) != 2 || texts[1].text != "Its cheeks crackle." {
+		t.Errorf("unexpected entries: %+v", texts)
+	}
+	config.Settings.Language = "fr"
+	if texts := flavorTexts(config, species); len(texts) != 1 || texts[0].versions[0] != "x" {
+		t.Errorf("unexpected entries: %+v", texts)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultHistorySize is how many commands are remembered by default.
+const defaultHistorySize = 1000
+
+func defaultHistoryPath(profile string) string {
+	dir, err := profileDir(profile)
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "history")
+}
+
+// loadHistory reads the commands entered in earlier sessions from
+// config.HistoryPath.
+func loadHistory(config *Config) error {
+	if config.HistoryPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(config.HistoryPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read history file: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		addHistory(config, line)
+	}
+	return nil
+}
+
+// saveHistory writes the command history to config.HistoryPath.
+func saveHistory(config *Config) error {
+	if config.HistoryPath == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(config.HistoryPath), 0o755); err != nil {
+		return fmt.Errorf("unable to create history directory: %w", err)
+	}
+	data := ""
+	for _, line := range config.History {
+		data += line + "\n"
+	}
+	if err := os.WriteFile(config.HistoryPath, []byte(data), 0o600); err != nil {
+		return fmt.Errorf("unable to write history file: %w", err)
+	}
+	return nil
+}
+
+// addHistory appends a command to the history. An earlier entry for the same
+// command is removed, and the oldest are dropped beyond the history size.
+func addHistory(config *Config, line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	config.History = slices.DeleteFunc(config.History, func(entry string) bool {
+		return entry == line
+	})
+	config.History = append(config.History, line)
+	trimHistory(config)
+}
+
+func trimHistory(config *Config) {
+	if excess := len(config.History) - config.Settings.HistorySize; excess > 0 {
+		config.History = slices.Delete(config.History, 0, excess)
+	}
+}
+
+// expandHistory replaces "!!" at the start of a command with the last
+// command, and "!n" with the command numbered n by the history command.
+// Any other words are appended to the command recalled.
+func expandHistory(config *Config, line string) (string, error) {
+	trimmed := strings.TrimSpace(line)
+	event, rest, _ := strings.Cut(trimmed, " ")
+	if !strings.HasPrefix(event, "!") {
+		return line, nil
+	}
+	index := len(config.History) - 1
+	if event != "!!" {
+		n, err := strconv.Atoi(event[1:])
+		if err != nil {
+			return line, nil
+		}
+		index = n - 1
+	}
+	if index < 0 || index >= len(config.History) {
+		return "", fmt.Errorf("no command %s in the history", event)
+	}
+	expanded := config.History[index]
+	if rest != "" {
+		expanded += " " + rest
+	}
+	return expanded, nil
+}
+
+func commandHistory(config *Config, args []string) error {
+	search := strings.Join(args[1:], " ")
+	for i, line := range config.History {
+		if strings.Contains(line, search) {
+			fmt.Printf("%5d  %s\n", i+1, line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestAddHistory(t *testing.T) {
+	config := testConfig(1)
+	config.Settings.HistorySize = 3
+	for _, line := range []string{"map", "explore", " ", "map", "catch pikachu", "party"} {
+		addHistory(config, line)
+	}
+	expected := []string{"map", "catch pikachu", "party"}
+	if !slices.Equal(config.History, expected) {
+		t.Errorf("[Expected, Received]: [%v, %v]", expected, config.History)
+	}
+}
+
+func TestExpandHistory(t *testing.T) {
+	config := testConfig(1)
+	config.History = []string{"map", "inspect pikachu", "party"}
+	cases := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{input: "!!", expected: "party"},
+		{input: "!2", expected: "inspect pikachu"},
+		{input: "!2 --sprite", expected: "inspect pikachu --sprite"},
+		{input: "!4", err: true},
+		{input: "!0", err: true},
+		{input: "!x", expected: "!x"},
+		{input: "catch pikachu", expected: "catch pikachu"},
+	}
+	for _, c := range cases {
+		actual, err := expandHistory(config, c.input)
+		if (err != nil) != c.err {
+			t.Errorf("%q: unexpected error %v", c.input, err)
+			continue
+		}
+		if !c.err && actual != c.expected {
+			t.Errorf("[Expected, Received]: [%q, %q]", c.expected, actual)
+		}
+	}
+}
+
+func TestSaveHistory(t *testing.T) {
+	config := testConfig(1)
+	config.HistoryPath = filepath.Join(t.TempDir(), "history")
+	config.History = []string{"map", "explore"}
+	if err := saveHistory(config); err != nil {
+		t.Fatal(err)
+	}
+	loaded := testConfig(1)
+	loaded.HistoryPath = config.HistoryPath
+	if err := loadHistory(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(loaded.History, config.History) {
+		t.Errorf("[Expected, Received]: [%v, %v]", config.History, loaded.History)
+	}
+}
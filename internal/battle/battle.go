@@ -0,0 +1,440 @@
+// Package battle implements a turn-based, single battle between a trainer's
+// team and a wild Pokemon, following the main series rules.
+package battle
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// RNG is the source of randomness for a battle. *rand.Rand satisfies it.
+type RNG interface {
+	// IntN returns a number in [0, n).
+	IntN(n int) int
+}
+
+// Damage classes of a move.
+const (
+	Physical = "physical"
+	Special  = "special"
+	Status   = "status"
+)
+
+// Non-volatile status conditions, matching the capture package's names.
+const (
+	Sleep     = "sleep"
+	Freeze    = "freeze"
+	Paralysis = "paralysis"
+	Burn      = "burn"
+	Poison    = "poison"
+)
+
+type Move struct {
+	Name string
+	Type string
+	// Class is the damage class: Physical, Special or Status.
+	Class string
+	Power int
+	// Accuracy is the chance out of 100 of the move hitting; 0 never misses.
+	Accuracy int
+	PP       int
+	MaxPP    int
+	Priority int
+	// CritStage is the move's critical hit stage, 1 for high critical moves.
+	CritStage int
+}
+
+// struggle is used when a Pokemon has no PP left in any of its moves.
+var struggle = Move{Name: "struggle", Type: "typeless", Class: Physical, Power: 50}
+
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	// Stats holds attack, defense, special-attack, special-defense and speed.
+	Stats  map[string]int
+	HP     int
+	MaxHP  int
+	Status string
+	// SleepTurns counts the turns left asleep.
+	SleepTurns int
+	Moves      []*Move
+}
+
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+func (c *Combatant) speed() int {
+	if c.Status == Paralysis {
+		return c.Stats["speed"] / 2
+	}
+	return c.Stats["speed"]
+}
+
+// TypeChart maps an attacking type to the multipliers against defending types.
+// Missing entries are neutral.
+type TypeChart map[string]map[string]float64
+
+// Effectiveness returns the multiplier for an attack against a Pokemon of the
+// given types.
+func (chart TypeChart) Effectiveness(attack string, defend []string) float64 {
+	multiplier := 1.0
+	for _, defender := range defend {
+		if value, ok := chart[attack][defender]; ok {
+			multiplier *= value
+		}
+	}
+	return multiplier
+}
+
+type ActionKind int
+
+const (
+	// Fight uses the move at Action.Index.
+	Fight ActionKind = iota
+	// Switch sends out the team member at Action.Index.
+	Switch
+	// Item spends the turn on an item, such as throwing a ball.
+	Item
+	// Run attempts to flee.
+	Run
+)
+
+type Action struct {
+	Kind  ActionKind
+	Index int
+}
+
+type Outcome int
+
+const (
+	Ongoing Outcome = iota
+	Won
+	Lost
+	Fled
+)
+
+type Battle struct {
+	Team   []*Combatant
+	Active int
+	Wild   *Combatant
+	Chart  TypeChart
+	// Outcome is Ongoing until the battle is decided.
+	Outcome Outcome
+	// NeedsSwitch is set when the active Pokemon has fainted and another must
+	// be sent out before the battle can continue.
+	NeedsSwitch bool
+
+	rng         RNG
+	escapes     int
+	log         []string
+	participant []bool
+}
+
+func New(team []*Combatant, wild *Combatant, chart TypeChart, rng RNG) (*Battle, error) {
+	b := &Battle{
+		Team:        team,
+		Wild:        wild,
+		Chart:       chart,
+		rng:         rng,
+		participant: make([]bool, len(team)),
+	}
+	b.Active = slices.IndexFunc(team, func(c *Combatant) bool { return !c.Fainted() })
+	if b.Active < 0 {
+		return nil, fmt.Errorf("no Pokemon able to battle")
+	}
+	b.participant[b.Active] = true
+	return b, nil
+}
+
+func (b *Battle) Player() *Combatant {
+	return b.Team[b.Active]
+}
+
+// Participants returns the team members that have been sent out.
+func (b *Battle) Participants() []*Combatant {
+	participants := []*Combatant{}
+	for i, c := range b.Team {
+		if b.participant[i] {
+			participants = append(participants, c)
+		}
+	}
+	return participants
+}
+
+func (b *Battle) logf(format string, args ...any) {
+	b.log = append(b.log, fmt.Sprintf(format, args...))
+}
+
+// Turn plays out a turn with the player taking action and the wild Pokemon
+// choosing a move at random. It returns a description of what happened.
+func (b *Battle) Turn(action Action) ([]string, error) {
+	b.log = nil
+	if b.Outcome != Ongoing {
+		return nil, fmt.Errorf("the battle is over")
+	}
+	if b.NeedsSwitch && action.Kind != Switch {
+		return nil, fmt.Errorf("%s has fainted, switch to another Pokemon", b.Player().Name)
+	}
+
+	switch action.Kind {
+	case Fight:
+		if action.Index < 0 || action.Index >= len(b.Player().Moves) {
+			return nil, fmt.Errorf("%s doesn't know that move", b.Player().Name)
+		}
+		if b.Player().Moves[action.Index].PP <= 0 && b.hasPP(b.Player()) {
+			return nil, fmt.Errorf("there's no PP left for this move")
+		}
+	case Switch:
+		if err := b.canSwitch(action.Index); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.NeedsSwitch {
+		// Replacing a fainted Pokemon doesn't use up a turn.
+		b.switchTo(action.Index)
+		b.NeedsSwitch = false
+		return b.log, nil
+	}
+
+	switch action.Kind {
+	case Switch:
+		b.switchTo(action.Index)
+	case Item:
+	case Run:
+		if b.tryRun() {
+			return b.log, nil
+		}
+	}
+
+	wildMove := b.chooseMove(b.Wild)
+	if action.Kind != Fight {
+		b.useMove(b.Wild, b.Player(), wildMove)
+	} else {
+		playerMove := b.chooseMove(b.Player())
+		if b.Player().Moves[action.Index].PP > 0 {
+			playerMove = b.Player().Moves[action.Index]
+		}
+		if b.movesFirst(b.Player(), playerMove, b.Wild, wildMove) {
+			b.useMove(b.Player(), b.Wild, playerMove)
+			b.useMove(b.Wild, b.Player(), wildMove)
+		} else {
+			b.useMove(b.Wild, b.Player(), wildMove)
+			b.useMove(b.Player(), b.Wild, playerMove)
+		}
+	}
+	b.residual(b.Player())
+	b.residual(b.Wild)
+	b.checkFainted()
+	return b.log, nil
+}
+
+func (b *Battle) canSwitch(index int) error {
+	if index < 0 || index >= len(b.Team) {
+		return fmt.Errorf("there's no Pokemon in that slot")
+	}
+	if index == b.Active && !b.NeedsSwitch {
+		return fmt.Errorf("%s is already in battle", b.Team[index].Name)
+	}
+	if b.Team[index].Fainted() {
+		return fmt.Errorf("%s has no energy left to battle", b.Team[index].Name)
+	}
+	return nil
+}
+
+func (b *Battle) switchTo(index int) {
+	if !b.Player().Fainted() {
+		b.logf("%s, come back!", b.Player().Name)
+	}
+	b.Active = index
+	b.participant[index] = true
+	b.logf("Go! %s!", b.Player().Name)
+}
+
+// tryRun uses the main series escape formula, which favors faster Pokemon and
+// repeated attempts. As from Generation III, odds above 255 always escape.
+func (b *Battle) tryRun() bool {
+	b.escapes += 1
+	speed, wildSpeed := b.Player().speed(), max(b.Wild.speed(), 1)
+	odds := speed*128/wildSpeed + 30*b.escapes
+	if speed >= wildSpeed || odds > 255 || b.rng.IntN(256) < odds {
+		b.logf("Got away safely!")
+		b.Outcome = Fled
+		return true
+	}
+	b.logf("Can't escape!")
+	return false
+}
+
+func (b *Battle) hasPP(c *Combatant) bool {
+	return slices.ContainsFunc(c.Moves, func(m *Move) bool { return m.PP > 0 })
+}
+
+// chooseMove picks a random move with PP left, or Struggle if there are none.
+func (b *Battle) chooseMove(c *Combatant) *Move {
+	usable := []*Move{}
+	for _, move := range c.Moves {
+		if move.PP > 0 {
+			usable = append(usable, move)
+		}
+	}
+	if len(usable) == 0 {
+		return &struggle
+	}
+	return usable[b.rng.IntN(len(usable))]
+}
+
+// movesFirst reports whether first goes before second, by move priority then
+// speed, with speed ties decided at random.
+func (b *Battle) movesFirst(first *Combatant, firstMove *Move, second *Combatant, secondMove *Move) bool {
+	if firstMove.Priority != secondMove.Priority {
+		return firstMove.Priority > secondMove.Priority
+	}
+	if first.speed() != second.speed() {
+		return first.speed() > second.speed()
+	}
+	return b.rng.IntN(2) == 0
+}
+
+// canAct resolves the attacker's status before it moves.
+func (b *Battle) canAct(attacker *Combatant) bool {
+	switch attacker.Status {
+	case Sleep:
+		if attacker.SleepTurns > 0 {
+			attacker.SleepTurns -= 1
+			b.logf("%s is fast asleep.", attacker.Name)
+			return false
+		}
+		attacker.Status = ""
+		b.logf("%s woke up!", attacker.Name)
+	case Freeze:
+		if b.rng.IntN(5) != 0 {
+			b.logf("%s is frozen solid!", attacker.Name)
+			return false
+		}
+		attacker.Status = ""
+		b.logf("%s thawed out!", attacker.Name)
+	case Paralysis:
+		if b.rng.IntN(4) == 0 {
+			b.logf("%s is paralyzed! It can't move!", attacker.Name)
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Battle) useMove(attacker, defender *Combatant, move *Move) {
+	if attacker.Fainted() || defender.Fainted() || !b.canAct(attacker) {
+		return
+	}
+	if move != &struggle {
+		move.PP -= 1
+	}
+	b.logf("%s used %s!", attacker.Name, move.Name)
+	if move.Accuracy > 0 && b.rng.IntN(100) >= move.Accuracy {
+		b.logf("%s's attack missed!", attacker.Name)
+		return
+	}
+	if move.Class == Status || move.Power <= 0 {
+		b.logf("But nothing happened!")
+		return
+	}
+
+	effectiveness := 1.0
+	if move != &struggle {
+		effectiveness = b.Chart.Effectiveness(move.Type, defender.Types)
+	}
+	if effectiveness == 0 {
+		b.logf("It doesn't affect %s...", defender.Name)
+		return
+	}
+	critical := b.criticalHit(move)
+	damage := Damage(attacker, defender, move, effectiveness, critical, 85+b.rng.IntN(16))
+	defender.HP = max(defender.HP-damage, 0)
+	if critical {
+		b.logf("A critical hit!")
+	}
+	switch {
+	case effectiveness > 1:
+		b.logf("It's super effective!")
+	case effectiveness < 1:
+		b.logf("It's not very effective...")
+	}
+	b.logf("%s took %d damage (%d/%d HP).", defender.Name, damage, defender.HP, defender.MaxHP)
+	if move == &struggle {
+		recoil := max(attacker.MaxHP/4, 1)
+		attacker.HP = max(attacker.HP-recoil, 0)
+		b.logf("%s is damaged by recoil!", attacker.Name)
+	}
+	if defender.Fainted() {
+		b.logf("%s fainted!", defender.Name)
+	}
+}
+
+// criticalHit rolls for a critical hit using the Generation VII+ odds.
+func (b *Battle) criticalHit(move *Move) bool {
+	odds := []int{24, 8, 2, 1}
+	return b.rng.IntN(odds[min(max(move.CritStage, 0), len(odds)-1)]) == 0
+}
+
+// Damage returns the damage dealt by move using the main series formula, where
+// random is the random factor out of 100, between 85 and 100.
+func Damage(attacker, defender *Combatant, move *Move, effectiveness float64, critical bool, random int) int {
+	attack, defense := attacker.Stats["attack"], defender.Stats["defense"]
+	if move.Class == Special {
+		attack, defense = attacker.Stats["special-attack"], defender.Stats["special-defense"]
+	}
+	damage := float64((2*attacker.Level/5+2)*move.Power*attack/max(defense, 1)/50 + 2)
+	if critical {
+		damage = math.Floor(damage * 1.5)
+	}
+	damage = math.Floor(damage * float64(random) / 100)
+	if slices.Contains(attacker.Types, move.Type) {
+		damage = math.Floor(damage * 1.5)
+	}
+	damage = math.Floor(damage * effectiveness)
+	if attacker.Status == Burn && move.Class == Physical {
+		damage = math.Floor(damage / 2)
+	}
+	return max(int(damage), 1)
+}
+
+// residual applies end of turn damage from poison and burns.
+func (b *Battle) residual(c *Combatant) {
+	if c.Fainted() {
+		return
+	}
+	var fraction int
+	switch c.Status {
+	case Poison:
+		fraction = 8
+	case Burn:
+		fraction = 16
+	default:
+		return
+	}
+	c.HP = max(c.HP-max(c.MaxHP/fraction, 1), 0)
+	b.logf("%s is hurt by its %s.", c.Name, c.Status)
+	if c.Fainted() {
+		b.logf("%s fainted!", c.Name)
+	}
+}
+
+func (b *Battle) checkFainted() {
+	if b.Wild.Fainted() {
+		b.Outcome = Won
+		return
+	}
+	if !b.Player().Fainted() {
+		return
+	}
+	if slices.ContainsFunc(b.Team, func(c *Combatant) bool { return !c.Fainted() }) {
+		b.NeedsSwitch = true
+		return
+	}
+	b.Outcome = Lost
+	b.logf("You have no more Pokemon that can fight!")
+}
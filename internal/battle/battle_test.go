@@ -0,0 +1,196 @@
+package battle
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestDamage(t *testing.T) {
+	// The level 75 Glaceon using Ice Fang on a Garchomp worked example.
+	glaceon := &Combatant{Name: "glaceon", Level: 75, Types: []string{"ice"}, Stats: map[string]int{"attack": 123}}
+	garchomp := &Combatant{Name: "garchomp", Types: []string{"dragon", "ground"}, Stats: map[string]int{"defense": 163}}
+	iceFang := &Move{Name: "ice-fang", Type: "ice", Class: Physical, Power: 65}
+	cases := []struct {
+		random   int
+		critical bool
+		expected int
+	}{
+		{random: 85, expected: 168},
+		{random: 100, expected: 196},
+		{random: 100, critical: true, expected: 292},
+	}
+	for _, c := range cases {
+		if actual := Damage(glaceon, garchomp, iceFang, 4, c.critical, c.random); actual != c.expected {
+			t.Errorf("[Expected, Received]: [%d, %d]", c.expected, actual)
+		}
+	}
+}
+
+func TestEffectiveness(t *testing.T) {
+	chart := TypeChart{
+		"ice":    {"dragon": 2, "ground": 2, "fire": 0.5},
+		"normal": {"ghost": 0},
+	}
+	cases := []struct {
+		attack   string
+		defend   []string
+		expected float64
+	}{
+		{attack: "ice", defend: []string{"dragon", "ground"}, expected: 4},
+		{attack: "ice", defend: []string{"fire", "dragon"}, expected: 1},
+		{attack: "normal", defend: []string{"ghost", "poison"}, expected: 0},
+		{attack: "water", defend: []string{"rock"}, expected: 1},
+	}
+	for _, c := range cases {
+		if actual := chart.Effectiveness(c.attack, c.defend); actual != c.expected {
+			t.Errorf("%s vs %v: [Expected, Received]: [%v, %v]", c.attack, c.defend, c.expected, actual)
+		}
+	}
+}
+
+func combatant(name string, level, hp, speed int, moves ...*Move) *Combatant {
+	return &Combatant{
+		Name:  name,
+		Level: level,
+		Types: []string{"normal"},
+		Stats: map[string]int{"attack": 50, "defense": 50, "special-attack": 50, "special-defense": 50, "speed": speed},
+		HP:    hp,
+		MaxHP: hp,
+		Moves: moves,
+	}
+}
+
+func tackle() *Move {
+	return &Move{Name: "tackle", Type: "normal", Class: Physical, Power: 40, PP: 35, MaxPP: 35}
+}
+
+func indexOf(log []string, prefix string) int {
+	for i, line := range log {
+		if strings.HasPrefix(line, prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestTurnOrder(t *testing.T) {
+	fast := combatant("fast", 10, 100, 90, tackle())
+	slow := combatant("slow", 10, 100, 30, tackle())
+	b, err := New([]*Combatant{slow}, fast, TypeChart{}, rand.New(rand.NewPCG(1, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := b.Turn(Action{Kind: Fight})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indexOf(log, "fast used") > indexOf(log, "slow used") {
+		t.Errorf("expected the faster Pokemon to move first: %v", log)
+	}
+
+	quick := &Move{Name: "quick-attack", Type: "normal", Class: Physical, Power: 40, PP: 30, Priority: 1}
+	slow.Moves = append(slow.Moves, quick)
+	log, err = b.Turn(Action{Kind: Fight, Index: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indexOf(log, "slow used") > indexOf(log, "fast used") {
+		t.Errorf("expected the priority move to go first: %v", log)
+	}
+	if quick.PP != 29 {
+		t.Errorf("expected quick-attack to use PP, has %d", quick.PP)
+	}
+}
+
+func TestFaintAndSwitch(t *testing.T) {
+	first := combatant("first", 5, 1, 10, tackle())
+	second := combatant("second", 5, 30, 10, tackle())
+	wild := combatant("wild", 50, 200, 100, tackle())
+	b, err := New([]*Combatant{first, second}, wild, TypeChart{}, rand.New(rand.NewPCG(2, 2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Turn(Action{Kind: Fight}); err != nil {
+		t.Fatal(err)
+	}
+	if !first.Fainted() || !b.NeedsSwitch {
+		t.Fatalf("expected first to faint and a switch to be needed")
+	}
+	if _, err := b.Turn(Action{Kind: Fight}); err == nil {
+		t.Errorf("expected fighting with a fainted Pokemon to fail")
+	}
+	if _, err := b.Turn(Action{Kind: Switch, Index: 0}); err == nil {
+		t.Errorf("expected switching to a fainted Pokemon to fail")
+	}
+	if _, err := b.Turn(Action{Kind: Switch, Index: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if b.Player() != second || second.HP != second.MaxHP {
+		t.Errorf("expected second to come in without being attacked")
+	}
+	for b.Outcome == Ongoing {
+		if _, err := b.Turn(Action{Kind: Fight}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Outcome != Lost {
+		t.Errorf("expected the battle to be lost")
+	}
+	if len(b.Participants()) != 2 {
+		t.Errorf("expected both team members to have participated")
+	}
+}
+
+func TestImmunity(t *testing.T) {
+	attacker := combatant("attacker", 50, 100, 100, tackle())
+	ghost := combatant("ghost", 50, 100, 1, &Move{Name: "splash", Type: "water", Class: Status, PP: 40})
+	ghost.Types = []string{"ghost"}
+	b, _ := New([]*Combatant{attacker}, ghost, TypeChart{"normal": {"ghost": 0}}, rand.New(rand.NewPCG(3, 3)))
+	log, err := b.Turn(Action{Kind: Fight})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ghost.HP != ghost.MaxHP || indexOf(log, "It doesn't affect") < 0 {
+		t.Errorf("expected no damage to a ghost: %v", log)
+	}
+}
+
+func TestSeededBattlesRepeat(t *testing.T) {
+	play := func() []string {
+		player := combatant("player", 20, 60, 50, tackle())
+		wild := combatant("wild", 20, 60, 50, tackle())
+		b, _ := New([]*Combatant{player}, wild, TypeChart{}, rand.New(rand.NewPCG(9, 9)))
+		all := []string{}
+		for b.Outcome == Ongoing {
+			log, _ := b.Turn(Action{Kind: Fight})
+			all = append(all, log...)
+		}
+		return all
+	}
+	first, second := play(), play()
+	if strings.Join(first, "\n") != strings.Join(second, "\n") {
+		t.Errorf("expected battles with the same seed to play out the same")
+	}
+}
+
+// worstRNG always rolls the highest number.
+type worstRNG struct{}
+
+func (worstRNG) IntN(n int) int {
+	return n - 1
+}
+
+func TestRunOddsAbove255(t *testing.T) {
+	player := combatant("player", 20, 60, 100, tackle())
+	wild := combatant("wild", 20, 60, 101, tackle())
+	b, _ := New([]*Combatant{player}, wild, TypeChart{}, worstRNG{})
+	// On the fifth attempt the odds are 100*128/101 + 30*5 = 276.
+	b.escapes = 4
+	if !b.tryRun() {
+		t.Errorf("expected odds above 255 to always escape")
+	}
+	if b.Outcome != Fled {
+		t.Errorf("[Expected, Received]: [%v, %v]", Fled, b.Outcome)
+	}
+}
@@ -0,0 +1,393 @@
+// Package capture implements the catch formulas used across the main series
+// Pokemon games.
+package capture
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Input describes a single throw at a wild Pokemon.
+type Input struct {
+	// CatchRate is the species' catch rate, including any additive ball bonus.
+	CatchRate int
+	// Ball is the ball's catch rate multiplier, and BallName the ball, such
+	// as "ultra-ball", for formulas that treat balls individually.
+	Ball      float64
+	BallName  string
+	MaxHP     int
+	CurrentHP int
+	Status    Status
+	// Registered is the number of species caught so far, which drives the
+	// chance of a critical capture.
+	Registered int
+}
+
+// hp returns the throw's HP values, treating an unknown HP as full.
+func (in Input) hp() (int, int) {
+	if in.MaxHP <= 0 {
+		return 1, 1
+	}
+	return in.MaxHP, min(max(in.CurrentHP, 1), in.MaxHP)
+}
+
+// Status is a non-volatile status condition, which makes a Pokemon easier to
+// catch.
+type Status string
+
+const (
+	StatusNone Status = ""
+	Sleep      Status = "sleep"
+	Freeze     Status = "freeze"
+	Paralysis  Status = "paralysis"
+	Burn       Status = "burn"
+	Poison     Status = "poison"
+)
+
+// Statuses lists the status conditions in order.
+var Statuses = []Status{Sleep, Freeze, Paralysis, Burn, Poison}
+
+// severe reports whether the status gives the larger catch bonus.
+func (status Status) severe() bool {
+	return status == Sleep || status == Freeze
+}
+
+type Result struct {
+	Shakes   int
+	Caught   bool
+	Critical bool
+}
+
+// RNG is the source of randomness for a throw. *rand.Rand satisfies it.
+type RNG interface {
+	// IntN returns a number in [0, n).
+	IntN(n int) int
+}
+
+type Formula interface {
+	// Name describes the games the formula is used in.
+	Name() string
+	// Probability returns the exact chance of the throw succeeding.
+	Probability(in Input) float64
+	// Throw performs the throw.
+	Throw(in Input, rng RNG) Result
+}
+
+var formulas = map[string]Formula{
+	"gen1": GenI{},
+	"gen2": GenII{},
+	"gen3": GenIII{},
+	"gen5": GenV{},
+	"gen6": GenVI{},
+}
+
+// Default is the name of the formula used unless another is selected.
+const Default = "gen3"
+
+// Get returns the formula registered under name.
+func Get(name string) (Formula, error) {
+	formula, ok := formulas[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown catch formula %q, expecting one of: %v", name, Names())
+	}
+	return formula, nil
+}
+
+// Names returns the names of the registered formulas in order.
+func Names() []string {
+	return slices.Sorted(maps.Keys(formulas))
+}
+
+// modifiedRate is the "a" value shared by the Generation III onward formulas,
+// where severe is the status multiplier for sleep and freeze.
+func modifiedRate(in Input, severe float64) float64 {
+	maxHP, currentHP := in.hp()
+	status := 1.0
+	switch {
+	case in.Status.severe():
+		status = severe
+	case in.Status != StatusNone:
+		status = 1.5
+	}
+	return math.Floor(float64(3*maxHP-2*currentHP) * float64(in.CatchRate) * in.Ball * status / float64(3*maxHP))
+}
+
+// shakeChecks performs up to checks checks, each passing with chance
+// threshold/65536, and returns the number that passed in a row.
+func shakeChecks(threshold int, checks int, rng RNG) int {
+	passed := 0
+	for passed < checks && rng.IntN(65_536) < threshold {
+		passed += 1
+	}
+	return passed
+}
+
+// shakeResult converts a run of passed shake checks into a result, where all
+// checks passing means the Pokemon was caught. The ball never shakes more than
+// three times.
+func shakeResult(passed int, checks int) Result {
+	return Result{
+		Shakes: min(passed, 3),
+		Caught: passed == checks,
+	}
+}
+
+// GenI is the formula used in Pokemon Red, Green, Blue and Yellow.
+type GenI struct{}
+
+func (GenI) Name() string {
+	return "Generation I"
+}
+
+// ballRange returns the upper bound of the first random number, which is
+// how Generation I distinguishes balls. Balls from later games act as Poke
+// Balls.
+func (GenI) ballRange(in Input) int {
+	switch in.BallName {
+	case "ultra-ball", "safari-ball":
+		return 150
+	case "great-ball":
+		return 200
+	default:
+		return 255
+	}
+}
+
+func (GenI) hpFactor(in Input) int {
+	maxHP, currentHP := in.hp()
+	ballFactor := 12
+	if in.BallName == "great-ball" {
+		ballFactor = 8
+	}
+	f := (maxHP * 255 / ballFactor) / max(currentHP/4, 1)
+	return min(f, 255)
+}
+
+// statusBonus returns the status values used by the catch check and the
+// shake animation.
+func (GenI) statusBonus(in Input) (int, int) {
+	switch {
+	case in.Status.severe():
+		return 25, 10
+	case in.Status != StatusNone:
+		return 12, 5
+	default:
+		return 0, 0
+	}
+}
+
+func (g GenI) Probability(in Input) float64 {
+	n := g.ballRange(in)
+	f := g.hpFactor(in)
+	s, _ := g.statusBonus(in)
+	statusRate := float64(s) / float64(n+1)
+	passRate := float64(min(in.CatchRate+1, n-s+1)) / float64(n+1)
+	return statusRate + passRate*float64(f+1)/256.0
+}
+
+func (g GenI) Throw(in Input, rng RNG) Result {
+	n := g.ballRange(in)
+	f := g.hpFactor(in)
+	s, shakeBonus := g.statusBonus(in)
+	r := rng.IntN(n + 1)
+	if r < s || (r-s <= in.CatchRate && rng.IntN(256) <= f) {
+		return Result{Shakes: 3, Caught: true}
+	}
+	d := in.CatchRate * 100 / n
+	if d >= 256 {
+		return Result{Shakes: 3}
+	}
+	x := d*f/255 + shakeBonus
+	switch {
+	case x < 10:
+		return Result{Shakes: 0}
+	case x < 30:
+		return Result{Shakes: 1}
+	case x < 70:
+		return Result{Shakes: 2}
+	default:
+		return Result{Shakes: 3}
+	}
+}
+
+// GenII is the formula used in Pokemon Gold, Silver and Crystal.
+type GenII struct{}
+
+func (GenII) Name() string {
+	return "Generation II"
+}
+
+func (GenII) rate(in Input) int {
+	maxHP, currentHP := in.hp()
+	// HP is scaled down to fit in a byte.
+	if maxHP > 255 {
+		maxHP, currentHP = maxHP/4, max(currentHP/4, 1)
+	}
+	rate := min(math.Floor(float64(in.CatchRate)*in.Ball), 255)
+	a := max(math.Floor(float64(3*maxHP-2*currentHP)*rate/float64(3*maxHP)), 1)
+	// Only sleep and freeze help in Generation II; the bonus for the other
+	// conditions is never applied due to a bug in the games.
+	if in.Status.severe() {
+		a += 10
+	}
+	return int(min(a, 255))
+}
+
+func (g GenII) Probability(in Input) float64 {
+	return float64(g.rate(in)+1) / 256.0
+}
+
+// genIIShakes maps the modified catch rate to the chance out of 256 of each
+// shake check passing in Generation II: entry i applies to rates up to
+// genIIShakes[i].rate.
+var genIIShakes = []struct {
+	rate      int
+	threshold int
+}{
+	{1, 63}, {2, 75}, {3, 84}, {4, 90}, {5, 95}, {7, 103}, {10, 113},
+	{15, 126}, {20, 134}, {30, 149}, {40, 160}, {50, 169}, {60, 177},
+	{80, 191}, {100, 201}, {120, 211}, {140, 220}, {160, 227}, {180, 234},
+	{200, 240}, {220, 246}, {240, 251}, {254, 253}, {255, 255},
+}
+
+func genIIShakeThreshold(a int) int {
+	for _, shake := range genIIShakes {
+		if a <= shake.rate {
+			return shake.threshold
+		}
+	}
+	return 255
+}
+
+func (g GenII) Throw(in Input, rng RNG) Result {
+	a := g.rate(in)
+	if a >= 255 || rng.IntN(256) <= a {
+		return Result{Shakes: 3, Caught: true}
+	}
+	threshold := genIIShakeThreshold(a)
+	shakes := 0
+	for shakes < 3 && rng.IntN(256) < threshold {
+		shakes += 1
+	}
+	return Result{Shakes: shakes}
+}
+
+// GenIII is the formula used in Generations III and IV.
+type GenIII struct{}
+
+func (GenIII) Name() string {
+	return "Generations III-IV"
+}
+
+func genIIIShakeThreshold(a float64) int {
+	a = max(a, 1)
+	return int(math.Floor(1_048_560 / math.Floor(math.Sqrt(
+		math.Floor(math.Sqrt(math.Floor(16_711_680/a)))))))
+}
+
+func (GenIII) Probability(in Input) float64 {
+	a := modifiedRate(in, 2)
+	if a >= 255 {
+		return 1
+	}
+	return math.Pow(float64(genIIIShakeThreshold(a))/65_536, 4)
+}
+
+func (GenIII) Throw(in Input, rng RNG) Result {
+	a := modifiedRate(in, 2)
+	if a >= 255 {
+		return Result{Shakes: 3, Caught: true}
+	}
+	return shakeResult(shakeChecks(genIIIShakeThreshold(a), 4, rng), 4)
+}
+
+// criticalRate returns the chance out of 256 of a critical capture, which
+// grows with the number of species registered.
+func criticalRate(a float64, registered int) int {
+	multiplier := 0.0
+	switch {
+	case registered > 600:
+		multiplier = 2.5
+	case registered > 450:
+		multiplier = 2
+	case registered > 300:
+		multiplier = 1.5
+	case registered > 150:
+		multiplier = 1
+	case registered > 30:
+		multiplier = 0.5
+	}
+	return int(math.Floor(min(a, 255) * multiplier / 6))
+}
+
+// modernFormula covers Generation V onward, which differ only in the shake
+// threshold exponent and the number of shake checks. Sleep and freeze give a
+// 2.5x bonus from Generation V.
+type modernFormula struct {
+	exponent float64
+	checks   int
+}
+
+const modernSevereStatus = 2.5
+
+func (m modernFormula) threshold(a float64) int {
+	return int(math.Floor(65_536 / math.Pow(255/max(a, 1), m.exponent)))
+}
+
+func (m modernFormula) probability(in Input) float64 {
+	a := modifiedRate(in, modernSevereStatus)
+	if a >= 255 {
+		return 1
+	}
+	p := float64(m.threshold(a)) / 65_536
+	critical := float64(criticalRate(a, in.Registered)) / 256
+	return critical*p + (1-critical)*math.Pow(p, float64(m.checks))
+}
+
+func (m modernFormula) throw(in Input, rng RNG) Result {
+	a := modifiedRate(in, modernSevereStatus)
+	if a >= 255 {
+		return Result{Shakes: 3, Caught: true}
+	}
+	if rng.IntN(256) < criticalRate(a, in.Registered) {
+		passed := shakeChecks(m.threshold(a), 1, rng)
+		return Result{Shakes: passed, Caught: passed == 1, Critical: true}
+	}
+	return shakeResult(shakeChecks(m.threshold(a), m.checks, rng), m.checks)
+}
+
+// GenV is the formula used in Pokemon Black, White, Black 2 and White 2.
+type GenV struct{}
+
+func (GenV) Name() string {
+	return "Generation V"
+}
+
+var genV = modernFormula{exponent: 1.0 / 4.0, checks: 3}
+
+func (GenV) Probability(in Input) float64 {
+	return genV.probability(in)
+}
+
+func (GenV) Throw(in Input, rng RNG) Result {
+	return genV.throw(in, rng)
+}
+
+// GenVI is the formula used from Pokemon X and Y onward.
+type GenVI struct{}
+
+func (GenVI) Name() string {
+	return "Generation VI+"
+}
+
+var genVI = modernFormula{exponent: 3.0 / 16.0, checks: 4}
+
+func (GenVI) Probability(in Input) float64 {
+	return genVI.probability(in)
+}
+
+func (GenVI) Throw(in Input, rng RNG) Result {
+	return genVI.throw(in, rng)
+}
@@ -0,0 +1,137 @@
+package capture
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestProbability(t *testing.T) {
+	// Reference values are the worked examples for each generation's formula:
+	// a catch rate 45 Pokemon (the starters) at full HP in a Poke Ball, and at
+	// 1 HP in an Ultra Ball.
+	fullHP := Input{CatchRate: 45, Ball: 1.0, MaxHP: 20, CurrentHP: 20}
+	lowHP := Input{CatchRate: 45, Ball: 2.0, BallName: "ultra-ball", MaxHP: 100, CurrentHP: 1}
+	asleep := fullHP
+	asleep.Status = Sleep
+	paralyzed := fullHP
+	paralyzed.Status = Paralysis
+	cases := []struct {
+		formula  string
+		input    Input
+		expected float64
+	}{
+		{formula: "gen1", input: fullHP, expected: (46.0 / 256) * (86.0 / 256)},
+		{formula: "gen1", input: lowHP, expected: (46.0 / 151) * (256.0 / 256)},
+		{formula: "gen1", input: asleep, expected: 25.0/256 + (46.0/256)*(86.0/256)},
+		{formula: "gen1", input: paralyzed, expected: 12.0/256 + (46.0/256)*(86.0/256)},
+		{formula: "gen2", input: fullHP, expected: 16.0 / 256},
+		{formula: "gen2", input: asleep, expected: 26.0 / 256},
+		{formula: "gen2", input: paralyzed, expected: 16.0 / 256},
+		{formula: "gen2", input: lowHP, expected: 90.0 / 256},
+		{formula: "gen3", input: fullHP, expected: math.Pow(32_767.0/65_536, 4)},
+		{formula: "gen3", input: lowHP, expected: math.Pow(52_428.0/65_536, 4)},
+		{formula: "gen3", input: asleep, expected: math.Pow(38_835.0/65_536, 4)},
+		{formula: "gen3", input: paralyzed, expected: math.Pow(36_157.0/65_536, 4)},
+		{formula: "gen5", input: fullHP, expected: math.Pow(32_275.0/65_536, 3)},
+		{formula: "gen6", input: fullHP, expected: math.Pow(38_527.0/65_536, 4)},
+		{
+			formula:  "gen6",
+			input:    Input{CatchRate: 45, Ball: 1.0, MaxHP: 20, CurrentHP: 20, Registered: 601},
+			expected: (6.0/256)*(38_527.0/65_536) + (250.0/256)*math.Pow(38_527.0/65_536, 4),
+		},
+		{
+			formula:  "gen1",
+			input:    Input{CatchRate: 45, Ball: 1.5, BallName: "safari-ball", MaxHP: 100, CurrentHP: 1},
+			expected: (46.0 / 151) * (256.0 / 256),
+		},
+		{
+			formula:  "gen1",
+			input:    Input{CatchRate: 45, Ball: 1.5, BallName: "great-ball", MaxHP: 20, CurrentHP: 20},
+			expected: (46.0 / 201) * (128.0 / 256),
+		},
+		{formula: "gen3", input: Input{CatchRate: 255, Ball: 3.0}, expected: 1},
+		{formula: "gen6", input: Input{CatchRate: 255, Ball: 3.0}, expected: 1},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			formula, err := Get(c.formula)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := formula.Probability(c.input)
+			if math.Abs(actual-c.expected) > 1e-9 {
+				t.Errorf("%s: [Expected, Received]: [%v, %v]", formula.Name(), c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestThrowMatchesProbability(t *testing.T) {
+	const trials = 50_000
+	inputs := []Input{
+		{CatchRate: 45, Ball: 1.0, MaxHP: 20, CurrentHP: 20},
+		{CatchRate: 120, Ball: 1.5, MaxHP: 50, CurrentHP: 10, Registered: 500},
+		{CatchRate: 3, Ball: 1.0, MaxHP: 200, CurrentHP: 50, Status: Sleep},
+		{CatchRate: 75, Ball: 2.0, MaxHP: 80, CurrentHP: 80, Status: Burn},
+	}
+	for _, name := range Names() {
+		formula, _ := Get(name)
+		for _, input := range inputs {
+			rng := rand.New(rand.NewPCG(1, 2))
+			caught := 0
+			for range trials {
+				if formula.Throw(input, rng).Caught {
+					caught += 1
+				}
+			}
+			expected := formula.Probability(input)
+			actual := float64(caught) / trials
+			if math.Abs(actual-expected) > 0.01 {
+				t.Errorf("%s: [Expected, Received]: [%.4f, %.4f]", formula.Name(), expected, actual)
+			}
+		}
+	}
+}
+
+func TestGetUnknown(t *testing.T) {
+	if _, err := Get("gen0"); err == nil {
+		t.Errorf("expected an error for an unknown formula")
+	}
+}
+
+func TestThrowSeeded(t *testing.T) {
+	input := Input{CatchRate: 45, Ball: 1.0, MaxHP: 20, CurrentHP: 5}
+	for _, name := range Names() {
+		formula, _ := Get(name)
+		first := rand.New(rand.NewPCG(3, 3))
+		second := rand.New(rand.NewPCG(3, 3))
+		for i := range 100 {
+			a, b := formula.Throw(input, first), formula.Throw(input, second)
+			if a != b {
+				t.Errorf("%s throw %d: expected equal seeds to give equal results, got %+v and %+v", formula.Name(), i, a, b)
+				break
+			}
+		}
+	}
+}
+
+func TestGenIIShakeThreshold(t *testing.T) {
+	cases := []struct {
+		rate     int
+		expected int
+	}{
+		{rate: 1, expected: 63},
+		{rate: 6, expected: 103},
+		{rate: 16, expected: 134},
+		{rate: 90, expected: 201},
+		{rate: 254, expected: 253},
+	}
+	for _, c := range cases {
+		if actual := genIIShakeThreshold(c.rate); actual != c.expected {
+			t.Errorf("[Expected, Received]: [%d, %d]", c.expected, actual)
+		}
+	}
+}
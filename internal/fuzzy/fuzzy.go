@@ -0,0 +1,72 @@
+// Package fuzzy finds the names closest to a mistyped one.
+package fuzzy
+
+import (
+	"slices"
+	"strings"
+)
+
+// Distance returns the number of single character insertions, deletions,
+// substitutions and transpositions of adjacent characters needed to turn a
+// into b.
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// Only the last three rows of the distance matrix are kept.
+	previous2 := make([]int, len(t)+1)
+	previous := make([]int, len(t)+1)
+	current := make([]int, len(t)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(t)]
+}
+
+// Suggest returns up to limit candidates that input may have been meant to
+// be: those it is a prefix of, then those within a few edits of it, closest
+// first.
+func Suggest(input string, candidates []string, limit int) []string {
+	type match struct {
+		name  string
+		score int
+	}
+	// Allow roughly one mistake for every three characters typed.
+	threshold := max(1, len([]rune(input))/3)
+	matches := []match{}
+	for _, candidate := range candidates {
+		if candidate == input {
+			continue
+		}
+		if strings.HasPrefix(candidate, input) {
+			matches = append(matches, match{name: candidate, score: 0})
+			continue
+		}
+		if distance := Distance(input, candidate); distance <= threshold {
+			matches = append(matches, match{name: candidate, score: distance})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int {
+		if a.score != b.score {
+			return a.score - b.score
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	names := []string{}
+	for _, match := range matches[:min(len(matches), limit)] {
+		names = append(names, match.name)
+	}
+	return names
+}
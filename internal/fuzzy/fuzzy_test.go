@@ -0,0 +1,43 @@
+package fuzzy
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "pikachu", b: "pikachu", expected: 0},
+		{a: "pikchu", b: "pikachu", expected: 1},
+		{a: "pikahcu", b: "pikachu", expected: 1},
+		{a: "bulbsaur", b: "bulbasaur", expected: 1},
+		{a: "", b: "abc", expected: 3},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+	for _, c := range cases {
+		if actual := Distance(c.a, c.b); actual != c.expected {
+			t.Errorf("%s, %s: [Expected, Received]: [%d, %d]", c.a, c.b, c.expected, actual)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"pikachu", "pichu", "raichu", "mt-moon-1f", "mt-moon-b1f", "mt-moon-b2f", "bulbasaur"}
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "pikchu", expected: []string{"pichu", "pikachu"}},
+		{input: "mt-moon", expected: []string{"mt-moon-1f", "mt-moon-b1f", "mt-moon-b2f"}},
+		{input: "mewtwo", expected: []string{}},
+		{input: "bulbsaur", expected: []string{"bulbasaur"}},
+	}
+	for _, c := range cases {
+		if actual := Suggest(c.input, candidates, 3); !slices.Equal(actual, c.expected) {
+			t.Errorf("%s: [Expected, Received]: [%v, %v]", c.input, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,436 @@
+// Package lineedit reads lines from a terminal with Emacs-style editing,
+// history recall, reverse search and tab completion. When the input isn't a
+// terminal, lines are read as they are.
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInterrupted is returned by ReadLine when the line is abandoned with
+// Ctrl-C.
+var ErrInterrupted = errors.New("interrupted")
+
+// Completer returns the words that could complete the last word of head,
+// the text before the cursor.
+type Completer func(head string) []string
+
+// Editor reads lines, keeping the history of lines entered.
+type Editor struct {
+	// Complete, when set, completes the word before the cursor on Tab.
+	Complete Completer
+	history  []string
+	in       *bufio.Reader
+	fd       uintptr
+	terminal bool
+	out      io.Writer
+}
+
+// New returns an editor reading from in and echoing to out.
+func New(in *os.File, out io.Writer) *Editor {
+	return &Editor{
+		in:       bufio.NewReader(in),
+		fd:       in.Fd(),
+		terminal: isTerminal(in.Fd()),
+		out:      out,
+	}
+}
+
+// SetHistory replaces the history recalled with the arrow keys and
+// searched with Ctrl-R, oldest first.
+func (e *Editor) SetHistory(lines []string) {
+	e.history = slices.Clone(lines)
+}
+
+// ReadLine prints the prompt and reads a line. It returns io.EOF when the
+// input ends or Ctrl-D is pressed on an empty line.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if e.terminal {
+		if restore, err := makeRaw(e.fd); err == nil {
+			defer restore()
+			return e.edit(prompt)
+		}
+	}
+	fmt.Fprint(e.out, prompt)
+	line, err := e.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// Control keys.
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlT     = 20
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyCtrlY     = 25
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// Keys read as escape sequences are given values past the end of Unicode.
+const (
+	keyUp rune = unicode.MaxRune + 1 + iota
+	keyDown
+	keyRight
+	keyLeft
+	keyHome
+	keyEnd
+	keyDelete
+	keyWordLeft
+	keyWordRight
+	keyDeleteWord
+	keyUnknown
+)
+
+// line is the state of the line being edited.
+type line struct {
+	prompt string
+	buf    []rune
+	pos    int
+	// entry is the history entry shown, len(history) for the new line, and
+	// draft the new line as it was before moving through the history.
+	entry int
+	draft []rune
+	// killed is the text last cut, for yanking.
+	killed []rune
+}
+
+func (e *Editor) edit(prompt string) (string, error) {
+	l := &line{prompt: prompt, entry: len(e.history)}
+	e.refresh(l)
+	for {
+		key, err := e.readKey()
+		if err != nil {
+			return "", err
+		}
+		if key == keyCtrlR {
+			key, err = e.search(l)
+			if err != nil {
+				return "", err
+			}
+		}
+		switch key {
+		case keyEnter, keyLineFeed:
+			fmt.Fprint(e.out, "\r\n")
+			return string(l.buf), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(l.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			l.deleteRight()
+		case keyCtrlA, keyHome:
+			l.pos = 0
+		case keyCtrlE, keyEnd:
+			l.pos = len(l.buf)
+		case keyCtrlB, keyLeft:
+			l.pos = max(l.pos-1, 0)
+		case keyCtrlF, keyRight:
+			l.pos = min(l.pos+1, len(l.buf))
+		case keyWordLeft:
+			l.pos = l.wordStart()
+		case keyWordRight:
+			l.pos = l.wordEnd()
+		case keyCtrlH, keyBackspace:
+			if l.pos > 0 {
+				l.pos--
+				l.deleteRight()
+			}
+		case keyDelete:
+			l.deleteRight()
+		case keyCtrlK:
+			l.kill(l.pos, len(l.buf))
+		case keyCtrlU:
+			l.kill(0, l.pos)
+		case keyCtrlW:
+			l.kill(l.wordStart(), l.pos)
+		case keyDeleteWord:
+			l.kill(l.pos, l.wordEnd())
+		case keyCtrlY:
+			l.insert(l.killed...)
+		case keyCtrlT:
+			l.transpose()
+		case keyCtrlP, keyUp:
+			e.recall(l, l.entry-1)
+		case keyCtrlN, keyDown:
+			e.recall(l, l.entry+1)
+		case keyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case keyTab:
+			e.complete(l)
+		default:
+			if key >= ' ' && key <= unicode.MaxRune && key != keyBackspace {
+				l.insert(key)
+			}
+		}
+		e.refresh(l)
+	}
+}
+
+// readKey reads a key, decoding the escape sequences sent for the arrow and
+// editing keys and for Alt combinations.
+func (e *Editor) readKey() (rune, error) {
+	key, _, err := e.in.ReadRune()
+	if err != nil || key != keyEscape {
+		return key, err
+	}
+	next, _, err := e.in.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	switch next {
+	case 'b':
+		return keyWordLeft, nil
+	case 'f':
+		return keyWordRight, nil
+	case 'd':
+		return keyDeleteWord, nil
+	case '[', 'O':
+	default:
+		return keyUnknown, nil
+	}
+	sequence := []rune{}
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		sequence = append(sequence, r)
+		if r >= 0x40 && r <= 0x7e {
+			break
+		}
+	}
+	switch string(sequence) {
+	case "A":
+		return keyUp, nil
+	case "B":
+		return keyDown, nil
+	case "C":
+		return keyRight, nil
+	case "D":
+		return keyLeft, nil
+	case "H", "1~", "7~":
+		return keyHome, nil
+	case "F", "4~", "8~":
+		return keyEnd, nil
+	case "3~":
+		return keyDelete, nil
+	case "1;5D", "1;3D":
+		return keyWordLeft, nil
+	case "1;5C", "1;3C":
+		return keyWordRight, nil
+	}
+	return keyUnknown, nil
+}
+
+// refresh redraws the line and places the cursor.
+func (e *Editor) refresh(l *line) {
+	e.draw(l.prompt, string(l.buf), len(l.buf)-l.pos)
+}
+
+func (e *Editor) draw(prompt, text string, back int) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", prompt, text)
+	if back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+func (l *line) insert(runes ...rune) {
+	l.buf = slices.Insert(l.buf, l.pos, runes...)
+	l.pos += len(runes)
+}
+
+func (l *line) deleteRight() {
+	if l.pos < len(l.buf) {
+		l.buf = slices.Delete(l.buf, l.pos, l.pos+1)
+	}
+}
+
+// kill cuts buf[from:to], keeping it for yanking.
+func (l *line) kill(from, to int) {
+	if from >= to {
+		return
+	}
+	l.killed = slices.Clone(l.buf[from:to])
+	l.buf = slices.Delete(l.buf, from, to)
+	l.pos = from
+}
+
+// transpose swaps the characters either side of the cursor, or the last two
+// at the end of the line.
+func (l *line) transpose() {
+	if len(l.buf) < 2 || l.pos == 0 {
+		return
+	}
+	if l.pos == len(l.buf) {
+		l.pos--
+	}
+	l.buf[l.pos-1], l.buf[l.pos] = l.buf[l.pos], l.buf[l.pos-1]
+	l.pos++
+}
+
+// wordStart returns the start of the word before the cursor.
+func (l *line) wordStart() int {
+	i := l.pos
+	for i > 0 && unicode.IsSpace(l.buf[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(l.buf[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the word after the cursor.
+func (l *line) wordEnd() int {
+	i := l.pos
+	for i < len(l.buf) && unicode.IsSpace(l.buf[i]) {
+		i++
+	}
+	for i < len(l.buf) && !unicode.IsSpace(l.buf[i]) {
+		i++
+	}
+	return i
+}
+
+// recall shows a history entry, or the new line past the end of the history.
+func (e *Editor) recall(l *line, entry int) {
+	if entry < 0 || entry > len(e.history) || entry == l.entry {
+		return
+	}
+	if l.entry == len(e.history) {
+		l.draft = slices.Clone(l.buf)
+	}
+	l.entry = entry
+	if entry == len(e.history) {
+		l.buf = slices.Clone(l.draft)
+	} else {
+		l.buf = []rune(e.history[entry])
+	}
+	l.pos = len(l.buf)
+}
+
+// search searches the history backwards as the query is typed, with Ctrl-R
+// moving to older matches. Enter or an editing key accepts the match into
+// the line and is returned to be handled as usual; Ctrl-G abandons the
+// search and returns 0.
+func (e *Editor) search(l *line) (rune, error) {
+	query := []rune{}
+	match := len(e.history)
+	find := func(from int) {
+		for i := min(from, len(e.history)-1); i >= 0; i-- {
+			if strings.Contains(e.history[i], string(query)) {
+				match = i
+				return
+			}
+		}
+	}
+	for {
+		found := ""
+		if match < len(e.history) {
+			found = e.history[match]
+		}
+		e.draw(fmt.Sprintf("(reverse-i-search)`%s': ", string(query)), found, 0)
+		key, err := e.readKey()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case key == keyCtrlR:
+			find(match - 1)
+		case key == keyCtrlH || key == keyBackspace:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				match = len(e.history)
+				find(match)
+			}
+		case key == keyCtrlG || key == keyCtrlC:
+			return 0, nil
+		case key >= ' ' && key <= unicode.MaxRune:
+			query = append(query, key)
+			find(match)
+		default:
+			if match < len(e.history) {
+				l.buf = []rune(found)
+				l.pos = len(l.buf)
+				l.entry = match
+			}
+			return key, nil
+		}
+	}
+}
+
+// complete completes the word before the cursor. A single match is completed
+// in full; otherwise the matches' common prefix is completed, or, when it adds
+// nothing, the matches are listed.
+func (e *Editor) complete(l *line) {
+	if e.Complete == nil {
+		return
+	}
+	start := l.pos
+	for start > 0 && !unicode.IsSpace(l.buf[start-1]) {
+		start--
+	}
+	word := string(l.buf[start:l.pos])
+	matches := e.Complete(string(l.buf[:l.pos]))
+	switch len(matches) {
+	case 0:
+		return
+	case 1:
+		l.replace(start, matches[0]+" ")
+		return
+	}
+	prefix := commonPrefix(matches)
+	if len(prefix) > len(word) {
+		l.replace(start, prefix)
+		return
+	}
+	fmt.Fprint(e.out, "\r\n"+strings.Join(matches, "  ")+"\r\n")
+}
+
+// replace replaces buf[from:pos] with text.
+func (l *line) replace(from int, text string) {
+	l.buf = slices.Delete(l.buf, from, l.pos)
+	l.pos = from
+	l.insert([]rune(text)...)
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,115 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func testEditor(input string, history ...string) *Editor {
+	return &Editor{
+		in:      bufio.NewReader(strings.NewReader(input)),
+		out:     io.Discard,
+		history: history,
+	}
+}
+
+func TestEdit(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		history  []string
+		expected string
+	}{
+		{name: "typing", input: "catch pikachu\r", expected: "catch pikachu"},
+		{name: "backspace", input: "catchh\x7f pikachu\r", expected: "catch pikachu"},
+		{name: "home and end", input: "pikachu\x01catch \x05!\r", expected: "catch pikachu!"},
+		{name: "arrow keys", input: "cach\x1b[D\x1b[Dt\x1b[C\x1b[C pikachu\r", expected: "catch pikachu"},
+		{name: "kill and yank", input: "catch pikachu\x17\x15\x19\r", expected: "catch "},
+		{name: "kill to end", input: "catch pikachu\x01\x1bf\x0b\r", expected: "catch"},
+		{name: "transpose", input: "cacth\x02\x02\x14\r", expected: "catch"},
+		{name: "delete", input: "catchx\x02\x1b[3~\r", expected: "catch"},
+		{name: "history", input: "\x1b[A\x1b[A\r", history: []string{"map", "explore"}, expected: "map"},
+		{name: "history draft", input: "ca\x10\x0e\r", history: []string{"map"}, expected: "ca"},
+		{name: "reverse search", input: "\x12ex\r", history: []string{"explore", "map", "exit"}, expected: "exit"},
+		{name: "reverse search older", input: "\x12ex\x12\r", history: []string{"explore", "map", "exit"}, expected: "explore"},
+		{name: "reverse search edit", input: "\x12ma\x05 --page 2\r", history: []string{"map"}, expected: "map --page 2"},
+		{name: "reverse search cancel", input: "m\x12ex\x07\r", history: []string{"exit"}, expected: "m"},
+	}
+	for _, c := range cases {
+		e := testEditor(c.input, c.history...)
+		actual, err := e.edit("> ")
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: [Expected, Received]: [%q, %q]", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestEditEnds(t *testing.T) {
+	if _, err := testEditor("\x04").edit("> "); err != io.EOF {
+		t.Errorf("[Expected, Received]: [%v, %v]", io.EOF, err)
+	}
+	if _, err := testEditor("map\x03").edit("> "); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("[Expected, Received]: [%v, %v]", ErrInterrupted, err)
+	}
+	if actual, err := testEditor("mapx\x02\x04\r").edit("> "); err != nil || actual != "map" {
+		t.Errorf("[Expected, Received]: [%q, %q]", "map", actual)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	complete := func(head string) []string {
+		words := []string{"catch", "cave", "pikachu"}
+		fields := strings.Fields(head)
+		word := ""
+		if len(fields) > 0 && !strings.HasSuffix(head, " ") {
+			word = fields[len(fields)-1]
+		}
+		matches := []string{}
+		for _, w := range words {
+			if strings.HasPrefix(w, word) {
+				matches = append(matches, w)
+			}
+		}
+		return matches
+	}
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "ca\t\r", expected: "ca"},
+		{input: "cat\t\r", expected: "catch "},
+		{input: "catch pi\tx\r", expected: "catch pikachu x"},
+		{input: "z\t\r", expected: "z"},
+	}
+	for _, c := range cases {
+		e := testEditor(c.input)
+		e.Complete = complete
+		actual, err := e.edit("> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != c.expected {
+			t.Errorf("[Expected, Received]: [%q, %q]", c.expected, actual)
+		}
+	}
+}
+
+func TestReadLineWithoutTerminal(t *testing.T) {
+	e := testEditor("map\nexplore")
+	for _, expected := range []string{"map", "explore"} {
+		actual, err := e.ReadLine("> ")
+		if err != nil || actual != expected {
+			t.Errorf("[Expected, Received]: [%q, %q] (%v)", expected, actual, err)
+		}
+	}
+	if _, err := e.ReadLine("> "); err != io.EOF {
+		t.Errorf("[Expected, Received]: [%v, %v]", io.EOF, err)
+	}
+}
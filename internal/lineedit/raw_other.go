@@ -0,0 +1,15 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package lineedit
+
+import "errors"
+
+// On other platforms lines are read without editing.
+
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+func makeRaw(fd uintptr) (func(), error) {
+	return nil, errors.New("raw mode is not supported on this platform")
+}
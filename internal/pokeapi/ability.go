@@ -0,0 +1,31 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type Ability struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	IsMainSeries  bool     `json:"is_main_series"`
+	Generation    Resource `json:"generation"`
+	EffectEntries []struct {
+		Effect      string   `json:"effect"`
+		ShortEffect string   `json:"short_effect"`
+		Language    Resource `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string   `json:"flavor_text"`
+		Language     Resource `json:"language"`
+		VersionGroup Resource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Pokemon []AbilityPokemon `json:"pokemon"`
+}
+
+type AbilityPokemon struct {
+	IsHidden bool     `json:"is_hidden"`
+	Slot     int      `json:"slot"`
+	Pokemon  Resource `json:"pokemon"`
+}
+
+func GetAbility(abilityName string, cache *pokecache.Cache) (Ability, error) {
+	return get[Ability](baseURL+"/ability/"+abilityName, cache)
+}
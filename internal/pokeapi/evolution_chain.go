@@ -0,0 +1,61 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type EvolutionChain struct {
+	ID              int       `json:"id"`
+	BabyTriggerItem *Resource `json:"baby_trigger_item"`
+	Chain           ChainLink `json:"chain"`
+}
+
+// ChainLink is a species in an evolution chain, with the species it evolves
+// into and the conditions for evolving into it.
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          Resource          `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail is one way of evolving. Conditions that don't apply are
+// null, false or empty.
+type EvolutionDetail struct {
+	Trigger               Resource  `json:"trigger"`
+	Item                  *Resource `json:"item"`
+	Gender                *int      `json:"gender"`
+	HeldItem              *Resource `json:"held_item"`
+	KnownMove             *Resource `json:"known_move"`
+	KnownMoveType         *Resource `json:"known_move_type"`
+	Location              *Resource `json:"location"`
+	MinLevel              *int      `json:"min_level"`
+	MinHappiness          *int      `json:"min_happiness"`
+	MinBeauty             *int      `json:"min_beauty"`
+	MinAffection          *int      `json:"min_affection"`
+	NeedsOverworldRain    bool      `json:"needs_overworld_rain"`
+	PartySpecies          *Resource `json:"party_species"`
+	PartyType             *Resource `json:"party_type"`
+	RelativePhysicalStats *int      `json:"relative_physical_stats"`
+	TimeOfDay             string    `json:"time_of_day"`
+	TradeSpecies          *Resource `json:"trade_species"`
+	TurnUpsideDown        bool      `json:"turn_upside_down"`
+}
+
+// Find returns the link for species within the chain, or nil if it isn't
+// part of it.
+func (link *ChainLink) Find(species string) *ChainLink {
+	if link.Species.Name == species {
+		return link
+	}
+	for i := range link.EvolvesTo {
+		if found := link.EvolvesTo[i].Find(species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// GetEvolutionChain fetches the chain at chainURL, as linked from a
+// PokemonSpecies.
+func GetEvolutionChain(chainURL string, cache *pokecache.Cache) (EvolutionChain, error) {
+	return get[EvolutionChain](chainURL, cache)
+}
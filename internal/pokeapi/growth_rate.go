@@ -0,0 +1,45 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type GrowthRate struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Formula      string `json:"formula"`
+	Descriptions []struct {
+		Description string   `json:"description"`
+		Language    Resource `json:"language"`
+	} `json:"descriptions"`
+	Levels         []GrowthRateExperienceLevel `json:"levels"`
+	PokemonSpecies []Resource                  `json:"pokemon_species"`
+}
+
+type GrowthRateExperienceLevel struct {
+	Level      int `json:"level"`
+	Experience int `json:"experience"`
+}
+
+func GetGrowthRate(growthRateName string, cache *pokecache.Cache) (GrowthRate, error) {
+	return get[GrowthRate](baseURL+"/growth-rate/"+growthRateName, cache)
+}
+
+// Experience returns the total experience needed to reach level.
+func (rate GrowthRate) Experience(level int) int {
+	for _, threshold := range rate.Levels {
+		if threshold.Level == level {
+			return threshold.Experience
+		}
+	}
+	return 0
+}
+
+// Level returns the level reached with the given total experience.
+func (rate GrowthRate) Level(experience int) int {
+	level := 1
+	for _, threshold := range rate.Levels {
+		if threshold.Experience <= experience && threshold.Level > level {
+			level = threshold.Level
+		}
+	}
+	return level
+}
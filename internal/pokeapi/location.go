@@ -0,0 +1,15 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type Location struct {
+	ID     int        `json:"id"`
+	Name   string     `json:"name"`
+	Names  []Name     `json:"names"`
+	Region Resource   `json:"region"`
+	Areas  []Resource `json:"areas"`
+}
+
+func GetLocation(locationName string, cache *pokecache.Cache) (Location, error) {
+	return get[Location](baseURL+"/location/"+locationName, cache)
+}
@@ -0,0 +1,42 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type Move struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Accuracy     *int     `json:"accuracy"`
+	EffectChance *int     `json:"effect_chance"`
+	PP           int      `json:"pp"`
+	Priority     int      `json:"priority"`
+	Power        *int     `json:"power"`
+	DamageClass  Resource `json:"damage_class"`
+	Type         Resource `json:"type"`
+	Generation   Resource `json:"generation"`
+	Meta         struct {
+		Ailment       Resource `json:"ailment"`
+		Category      Resource `json:"category"`
+		MinHits       *int     `json:"min_hits"`
+		MaxHits       *int     `json:"max_hits"`
+		Drain         int      `json:"drain"`
+		Healing       int      `json:"healing"`
+		CritRate      int      `json:"crit_rate"`
+		AilmentChance int      `json:"ailment_chance"`
+		FlinchChance  int      `json:"flinch_chance"`
+		StatChance    int      `json:"stat_chance"`
+	} `json:"meta"`
+	EffectEntries []struct {
+		Effect      string   `json:"effect"`
+		ShortEffect string   `json:"short_effect"`
+		Language    Resource `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string   `json:"flavor_text"`
+		Language     Resource `json:"language"`
+		VersionGroup Resource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+}
+
+func GetMove(moveName string, cache *pokecache.Cache) (Move, error) {
+	return get[Move](baseURL+"/move/"+moveName, cache)
+}
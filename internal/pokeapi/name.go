@@ -0,0 +1,20 @@
+package pokeapi
+
+import "strings"
+
+// Name is a resource's name in one language.
+type Name struct {
+	Name     string   `json:"name"`
+	Language Resource `json:"language"`
+}
+
+// Localized returns the name in language, such as "ja" or "zh-Hans", or ""
+// if there isn't one. Language codes are matched ignoring case.
+func Localized(names []Name, language string) string {
+	for _, name := range names {
+		if strings.EqualFold(name.Language.Name, language) {
+			return name.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type Nature struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	DecreasedStat Resource `json:"decreased_stat"`
+	IncreasedStat Resource `json:"increased_stat"`
+	HatesFlavor   Resource `json:"hates_flavor"`
+	LikesFlavor   Resource `json:"likes_flavor"`
+	Names         []Name   `json:"names"`
+}
+
+// NatureCount is the number of natures, which have IDs 1 to NatureCount.
+const NatureCount = 25
+
+func GetNature(nature string, cache *pokecache.Cache) (Nature, error) {
+	return get[Nature](baseURL+"/nature/"+nature, cache)
+}
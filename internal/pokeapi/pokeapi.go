@@ -1,5 +1,99 @@
 package pokeapi
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jthughes/pokedexcli/internal/pokecache"
+)
+
 const (
 	baseURL = "https://pokeapi.co/api/v2"
 )
+
+// ErrNotFound is returned when a resource doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrOffline is returned when a resource isn't cached and Offline is set.
+var ErrOffline = errors.New("not available offline")
+
+// Offline, when set, stops resources being fetched from the network, leaving
+// only those already cached.
+var Offline bool
+
+// NotFoundError is returned when there is no resource with a name at an API
+// endpoint, such as "pokemon". It matches ErrNotFound.
+type NotFoundError struct {
+	Endpoint string
+	Name     string
+}
+
+func (err *NotFoundError) Error() string {
+	return fmt.Sprintf("no %s named %s", strings.ReplaceAll(err.Endpoint, "-", " "), err.Name)
+}
+
+func (err *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// notFound returns the error for a missing url, naming the endpoint and
+// resource when the url is that of a named API resource.
+func notFound(url string) error {
+	path, ok := strings.CutPrefix(url, baseURL+"/")
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, url)
+	}
+	endpoint, name, ok := strings.Cut(strings.TrimSuffix(path, "/"), "/")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("%w: %s", ErrNotFound, url)
+	}
+	return &NotFoundError{Endpoint: endpoint, Name: name}
+}
+
+// fetch returns the body of url, going through the cache.
+func fetch(url string, cache *pokecache.Cache) ([]byte, error) {
+	data, ok := cache.Get(url)
+	if ok {
+		return data, nil
+	}
+	if Offline {
+		return nil, fmt.Errorf("%w: %s", ErrOffline, url)
+	}
+	response, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("network error: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, notFound(url)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("Non-OK HTTP status: %s", response.Status)
+	}
+
+	data, err = io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response body: %w", err)
+	}
+	cache.Add(url, data)
+	return data, nil
+}
+
+// get fetches url, going through the cache, and decodes the JSON body into T.
+func get[T any](url string, cache *pokecache.Cache) (T, error) {
+	var resource T
+
+	data, err := fetch(url, cache)
+	if err != nil {
+		return resource, err
+	}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return resource, fmt.Errorf("unable to unmarshall data: %w", err)
+	}
+	return resource, nil
+}
@@ -1,7 +1,97 @@
 package pokeapi
 
-import "testing"
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/pokecache"
+)
 
 func TestGetResourceList(t *testing.T) {
 
 }
+
+func TestGrowthRateLevel(t *testing.T) {
+	rate := GrowthRate{
+		Levels: []GrowthRateExperienceLevel{
+			{Level: 1, Experience: 0},
+			{Level: 2, Experience: 8},
+			{Level: 3, Experience: 27},
+			{Level: 4, Experience: 64},
+		},
+	}
+	cases := []struct {
+		experience int
+		expected   int
+	}{
+		{experience: 0, expected: 1},
+		{experience: 7, expected: 1},
+		{experience: 8, expected: 2},
+		{experience: 63, expected: 3},
+		{experience: 1000, expected: 4},
+	}
+	for _, c := range cases {
+		if actual := rate.Level(c.experience); actual != c.expected {
+			t.Errorf("[Expected, Received]: [%d, %d]", c.expected, actual)
+		}
+	}
+	if actual := rate.Experience(3); actual != 27 {
+		t.Errorf("[Expected, Received]: [%d, %d]", 27, actual)
+	}
+}
+
+func TestResourceID(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected int
+	}{
+		{url: "https://pokeapi.co/api/v2/version-group/20/", expected: 20},
+		{url: "https://pokeapi.co/api/v2/move/33", expected: 33},
+		{url: "", expected: 0},
+	}
+	for _, c := range cases {
+		if actual := (Resource{URL: c.url}).ID(); actual != c.expected {
+			t.Errorf("[Expected, Received]: [%d, %d]", c.expected, actual)
+		}
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected error
+	}{
+		{url: baseURL + "/pokemon/pikchu", expected: &NotFoundError{Endpoint: "pokemon", Name: "pikchu"}},
+		{url: baseURL + "/location-area/mt-moon/", expected: &NotFoundError{Endpoint: "location-area", Name: "mt-moon"}},
+		{url: baseURL + "/pokemon/pikachu/encounters", expected: nil},
+		{url: "https://raw.githubusercontent.com/PokeAPI/sprites/master/0.png", expected: nil},
+	}
+	for _, c := range cases {
+		err := notFound(c.url)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound, got %v", c.url, err)
+		}
+		var notFoundErr *NotFoundError
+		found := errors.As(err, &notFoundErr)
+		if c.expected == nil && found {
+			t.Errorf("%s: [Expected, Received]: [no resource, %v]", c.url, notFoundErr)
+		}
+		if c.expected != nil && (!found || *notFoundErr != *c.expected.(*NotFoundError)) {
+			t.Errorf("%s: [Expected, Received]: [%v, %v]", c.url, c.expected, err)
+		}
+	}
+}
+
+func TestOffline(t *testing.T) {
+	Offline = true
+	defer func() { Offline = false }()
+	cache := pokecache.NewCache(time.Minute)
+	cache.Add(baseURL+"/pokemon/pikachu", []byte(`{"name": "pikachu"}`))
+	if pokemon, err := GetPokemon("pikachu", cache); err != nil || pokemon.Name != "pikachu" {
+		t.Errorf("[Expected, Received]: [%q, %q] (%v)", "pikachu", pokemon.Name, err)
+	}
+	if _, err := GetPokemon("raichu", cache); !errors.Is(err, ErrOffline) {
+		t.Errorf("[Expected, Received]: [%v, %v]", ErrOffline, err)
+	}
+}
@@ -0,0 +1,31 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type Pokedex struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	IsMainSeries bool   `json:"is_main_series"`
+	Descriptions []struct {
+		Description string   `json:"description"`
+		Language    Resource `json:"language"`
+	} `json:"descriptions"`
+	Names          []Name         `json:"names"`
+	PokemonEntries []PokemonEntry `json:"pokemon_entries"`
+	Region         Resource       `json:"region"`
+	VersionGroups  []Resource     `json:"version_groups"`
+}
+
+type PokemonEntry struct {
+	EntryNumber    int      `json:"entry_number"`
+	PokemonSpecies Resource `json:"pokemon_species"`
+}
+
+type PokedexNumber struct {
+	EntryNumber int      `json:"entry_number"`
+	Pokedex     Resource `json:"pokedex"`
+}
+
+func GetPokedex(pokedexName string, cache *pokecache.Cache) (Pokedex, error) {
+	return get[Pokedex](baseURL+"/pokedex/"+pokedexName, cache)
+}
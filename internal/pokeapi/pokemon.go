@@ -1,13 +1,6 @@
 package pokeapi
 
-import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-
-	"github.com/jthughes/pokedexcli/internal/pokecache"
-)
+import "github.com/jthughes/pokedexcli/internal/pokecache"
 
 type Pokemon struct {
 	ID             int              `json:"id"`
@@ -30,21 +23,14 @@ type Pokemon struct {
 			Rarity  int      `json:"rarity"`
 		}
 	} `json:"held_items"`
-	LocationAreaEncounters string `json:"location_area_encounters"`
-	Moves                  []struct {
-		MoveLearnMethod Resource `json:"move_learn_method"`
-		VersionGroup    Resource `json:"version_group"`
-		LevelLearnedAt  int      `json:"level_learned_at"`
-	} `json:"moves"`
-	PastTypes []struct {
-		Generation Resource      `json:"generation"`
-		Types      []PokemonType `json:"types"`
-	} `json:"past_types"`
-	Sprites PokemonSprites `json:"sprites"`
-	Cries   PokemonCries   `json:"cries"`
-	Species Resource       `json:"species"`
-	Stats   []PokemonStat  `json:"stats"`
-	Types   []PokemonType  `json:"types"`
+	LocationAreaEncounters string             `json:"location_area_encounters"`
+	Moves                  []PokemonMove      `json:"moves"`
+	PastTypes              []PokemonPastTypes `json:"past_types"`
+	Sprites                PokemonSprites     `json:"sprites"`
+	Cries                  PokemonCries       `json:"cries"`
+	Species                Resource           `json:"species"`
+	Stats                  []PokemonStat      `json:"stats"`
+	Types                  []PokemonType      `json:"types"`
 }
 
 type PokemonAbility struct {
@@ -53,6 +39,22 @@ type PokemonAbility struct {
 	Ability  Resource `json:"ability"`
 }
 
+type PokemonMove struct {
+	Move                Resource             `json:"move"`
+	VersionGroupDetails []PokemonMoveVersion `json:"version_group_details"`
+}
+
+type PokemonMoveVersion struct {
+	MoveLearnMethod Resource `json:"move_learn_method"`
+	VersionGroup    Resource `json:"version_group"`
+	LevelLearnedAt  int      `json:"level_learned_at"`
+}
+
+type PokemonPastTypes struct {
+	Generation Resource      `json:"generation"`
+	Types      []PokemonType `json:"types"`
+}
+
 type PokemonType struct {
 	Slot int      `json:"slot"`
 	Type Resource `json:"type"`
@@ -81,62 +83,34 @@ type PokemonStat struct {
 }
 
 func GetPokemon(pokemonName string, cache *pokecache.Cache) (Pokemon, error) {
-	url := baseURL + "/pokemon/" + pokemonName
-
-	var data []byte
-	data, ok := cache.Get(url)
-	if !ok {
-		response, err := http.Get(url)
-		if err != nil {
-			return Pokemon{}, fmt.Errorf("network error: %w", err)
-		}
-		defer response.Body.Close()
-
-		if response.StatusCode < 200 || response.StatusCode > 299 {
-			return Pokemon{}, fmt.Errorf("Non-OK HTTP status: %s", response.Status)
-		}
-
-		data, err = io.ReadAll(response.Body)
-		if err != nil {
-			return Pokemon{}, fmt.Errorf("unable to read response body: %w", err)
-		}
-		cache.Add(url, data)
-	}
-	var resource Pokemon
-	if err := json.Unmarshal(data, &resource); err != nil {
-		return Pokemon{}, fmt.Errorf("unable to unmarshall data: %w", err)
-	}
-	return resource, nil
+	return get[Pokemon](baseURL+"/pokemon/"+pokemonName, cache)
 }
 
 type PokemonSpecies struct {
-	ID                   int        `json:"id"`
-	Name                 string     `json:"name"`
-	Order                int        `json:"order"`
-	GenderRate           int        `json:"gender_rate"`
-	CaptureRate          int        `json:"capture_rate"`
-	BaseHappiness        int        `json:"base_happiness"`
-	IsBaby               bool       `json:"is_baby"`
-	IsLegendary          bool       `json:"is_legendary"`
-	IsMythical           bool       `json:"is_mythical"`
-	HatchCounter         int        `json:"hatch_counter"`
-	HasGenderDifferences bool       `json:"has_gender_differences"`
-	FormsSwitchable      bool       `json:"forms_switchable"`
-	GrowthRate           Resource   `json:"growth_rate"`
-	PokedexNumbers       []Resource `json:"pokedex_numbers"`
-	EggGroups            []Resource `json:"egg_groups"`
-	Color                Resource   `json:"color"`
-	Shape                Resource   `json:"shape"`
-	EvolvesFromSpecies   Resource   `json:"evolves_from_species"`
+	ID                   int             `json:"id"`
+	Name                 string          `json:"name"`
+	Order                int             `json:"order"`
+	GenderRate           int             `json:"gender_rate"`
+	CaptureRate          int             `json:"capture_rate"`
+	BaseHappiness        int             `json:"base_happiness"`
+	IsBaby               bool            `json:"is_baby"`
+	IsLegendary          bool            `json:"is_legendary"`
+	IsMythical           bool            `json:"is_mythical"`
+	HatchCounter         int             `json:"hatch_counter"`
+	HasGenderDifferences bool            `json:"has_gender_differences"`
+	FormsSwitchable      bool            `json:"forms_switchable"`
+	GrowthRate           Resource        `json:"growth_rate"`
+	PokedexNumbers       []PokedexNumber `json:"pokedex_numbers"`
+	EggGroups            []Resource      `json:"egg_groups"`
+	Color                Resource        `json:"color"`
+	Shape                Resource        `json:"shape"`
+	EvolvesFromSpecies   Resource        `json:"evolves_from_species"`
 	EvolutionChain       struct {
 		Url string `json:"url"`
 	} `json:"evolution_chain"`
-	Habitat    Resource `json:"habitat"`
-	Generation Resource `json:"generation"`
-	Names      []struct {
-		Name     string   `json:"name"`
-		Language Resource `json:"language"`
-	} `json:"names"`
+	Habitat           Resource `json:"habitat"`
+	Generation        Resource `json:"generation"`
+	Names             []Name   `json:"names"`
 	PalParkEncounters []struct {
 		BaseScore int      `json:"base_score"`
 		Rate      int      `json:"rate"`
@@ -162,30 +136,5 @@ type PokemonSpecies struct {
 }
 
 func GetPokemonSpecies(pokemonName string, cache *pokecache.Cache) (PokemonSpecies, error) {
-	url := baseURL + "/pokemon-species/" + pokemonName
-
-	var data []byte
-	data, ok := cache.Get(url)
-	if !ok {
-		response, err := http.Get(url)
-		if err != nil {
-			return PokemonSpecies{}, fmt.Errorf("network error: %w", err)
-		}
-		defer response.Body.Close()
-
-		if response.StatusCode < 200 || response.StatusCode > 299 {
-			return PokemonSpecies{}, fmt.Errorf("Non-OK HTTP status: %s", response.Status)
-		}
-
-		data, err = io.ReadAll(response.Body)
-		if err != nil {
-			return PokemonSpecies{}, fmt.Errorf("unable to read response body: %w", err)
-		}
-		cache.Add(url, data)
-	}
-	var resource PokemonSpecies
-	if err := json.Unmarshal(data, &resource); err != nil {
-		return PokemonSpecies{}, fmt.Errorf("unable to unmarshall data: %w", err)
-	}
-	return resource, nil
+	return get[PokemonSpecies](baseURL+"/pokemon-species/"+pokemonName, cache)
 }
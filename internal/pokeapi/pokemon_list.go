@@ -1,13 +1,6 @@
 package pokeapi
 
-import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-
-	"github.com/jthughes/pokedexcli/internal/pokecache"
-)
+import "github.com/jthughes/pokedexcli/internal/pokecache"
 
 type Encounter struct {
 	Chance     int        `json:"chance"`
@@ -27,8 +20,8 @@ type EncounterMethodRates struct {
 
 type VersionEncounterDetail struct {
 	Version          Resource    `json:"version"`
-	MaxChange        int         `json:"max_chance"`
-	EncounterDetails []Encounter `json:"Encounter"`
+	MaxChance        int         `json:"max_chance"`
+	EncounterDetails []Encounter `json:"encounter_details"`
 }
 
 type PokemonEncounter struct {
@@ -42,38 +35,18 @@ type LocationArea struct {
 	ID                   int                    `json:"id"`
 	Location             Resource               `json:"location"`
 	Name                 string                 `json:"name"`
-	Names                []struct {
-		Name     string   `json:"name"`
-		Language Resource `json:"language"`
-	} `json:"names"`
-	Encounters []PokemonEncounter `json:"pokemon_encounters"`
+	Names                []Name                 `json:"names"`
+	Encounters           []PokemonEncounter     `json:"pokemon_encounters"`
 }
 
-func GetPokemonList(locationArea string, cache *pokecache.Cache) ([]PokemonEncounter, error) {
-	url := baseURL + "/location-area/" + locationArea
-
-	var data []byte
-	data, ok := cache.Get(url)
-	if !ok {
-		response, err := http.Get(url)
-		if err != nil {
-			return []PokemonEncounter{}, fmt.Errorf("network error: %w", err)
-		}
-		defer response.Body.Close()
-
-		if response.StatusCode < 200 || response.StatusCode > 299 {
-			return []PokemonEncounter{}, fmt.Errorf("Non-OK HTTP status: %s", response.Status)
-		}
+func GetLocationArea(locationArea string, cache *pokecache.Cache) (LocationArea, error) {
+	return get[LocationArea](baseURL+"/location-area/"+locationArea, cache)
+}
 
-		data, err = io.ReadAll(response.Body)
-		if err != nil {
-			return []PokemonEncounter{}, fmt.Errorf("unable to read response body: %w", err)
-		}
-		cache.Add(url, data)
-	}
-	var resources LocationArea
-	if err := json.Unmarshal(data, &resources); err != nil {
-		return []PokemonEncounter{}, fmt.Errorf("unable to unmarshall data: %w", err)
+func GetPokemonList(locationArea string, cache *pokecache.Cache) ([]PokemonEncounter, error) {
+	area, err := GetLocationArea(locationArea, cache)
+	if err != nil {
+		return []PokemonEncounter{}, err
 	}
-	return resources.Encounters, nil
+	return area.Encounters, nil
 }
@@ -3,8 +3,9 @@ package pokeapi
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"path"
+	"strconv"
+	"strings"
 
 	"github.com/jthughes/pokedexcli/internal/pokecache"
 )
@@ -21,37 +22,37 @@ type Resource struct {
 	URL  string `json:"url"`
 }
 
+// ID returns the ID at the end of the resource's URL, or 0 if there is none.
+func (r Resource) ID() int {
+	id, err := strconv.Atoi(path.Base(strings.TrimSuffix(r.URL, "/")))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
 func GetResourceList(pageURL *string, cache *pokecache.Cache) (ResourceList, error) {
 	url := baseURL + "/location-area"
 	if pageURL != nil {
 		url = *pageURL
 	}
 
-	var data []byte
-	data, ok := cache.Get(url)
-	if !ok {
-		response, err := http.Get(url)
-		if err != nil {
-			return ResourceList{}, fmt.Errorf("network error: %w", err)
-		}
-		defer response.Body.Close()
-
-		if response.StatusCode < 200 || response.StatusCode > 299 {
-			return ResourceList{}, fmt.Errorf("Non-OK HTTP status: %s", response.Status)
-		}
+	return get[ResourceList](url, cache)
+}
 
-		data, err = io.ReadAll(response.Body)
-		if err != nil {
-			return ResourceList{}, fmt.Errorf("unable to read response body: %w", err)
-		}
-		cache.Add(url, data)
-	}
+// PageURL returns the URL of a page of the resources listed by endpoint, such
+// as "location-area", counting pages from 1.
+func PageURL(endpoint string, page, size int) string {
+	return fmt.Sprintf("%s/%s?offset=%d&limit=%d", baseURL, endpoint, (page-1)*size, size)
+}
 
-	var resources ResourceList
-	if err := json.Unmarshal(data, &resources); err != nil {
-		return ResourceList{}, fmt.Errorf("unable to unmarshall data: %w", err)
+// GetResources returns every resource listed by endpoint, such as "type".
+func GetResources(endpoint string, cache *pokecache.Cache) ([]Resource, error) {
+	list, err := get[ResourceList](baseURL+"/"+endpoint+"?limit=100000", cache)
+	if err != nil {
+		return []Resource{}, err
 	}
-	return resources, nil
+	return list.Results, nil
 }
 
 func (r ResourceList) print() {
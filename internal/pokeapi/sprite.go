@@ -0,0 +1,8 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+// GetSprite fetches the PNG image at spriteURL, as listed in PokemonSprites.
+func GetSprite(spriteURL string, cache *pokecache.Cache) ([]byte, error) {
+	return fetch(spriteURL, cache)
+}
@@ -0,0 +1,39 @@
+package pokeapi
+
+import "github.com/jthughes/pokedexcli/internal/pokecache"
+
+type Type struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	DamageRelations TypeRelations `json:"damage_relations"`
+	// PastDamageRelations holds the relations the type had up to and
+	// including each listed generation.
+	PastDamageRelations []TypePastRelations `json:"past_damage_relations"`
+	Generation          Resource            `json:"generation"`
+	MoveDamageClass     Resource            `json:"move_damage_class"`
+	Pokemon             []TypePokemon       `json:"pokemon"`
+	Moves               []Resource          `json:"moves"`
+}
+
+type TypeRelations struct {
+	NoDamageTo       []Resource `json:"no_damage_to"`
+	HalfDamageTo     []Resource `json:"half_damage_to"`
+	DoubleDamageTo   []Resource `json:"double_damage_to"`
+	NoDamageFrom     []Resource `json:"no_damage_from"`
+	HalfDamageFrom   []Resource `json:"half_damage_from"`
+	DoubleDamageFrom []Resource `json:"double_damage_from"`
+}
+
+type TypePastRelations struct {
+	Generation      Resource      `json:"generation"`
+	DamageRelations TypeRelations `json:"damage_relations"`
+}
+
+type TypePokemon struct {
+	Slot    int      `json:"slot"`
+	Pokemon Resource `json:"pokemon"`
+}
+
+func GetType(typeName string, cache *pokecache.Cache) (Type, error) {
+	return get[Type](baseURL+"/type/"+typeName, cache)
+}
@@ -1,6 +1,10 @@
 package pokecache
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 )
@@ -8,6 +12,9 @@ import (
 type Cache struct {
 	store map[string]cacheEntry
 	mutex sync.Mutex
+	// dir, when set, is where entries are also kept on disk, so that they
+	// outlive the process.
+	dir string
 }
 
 type cacheEntry struct {
@@ -23,6 +30,14 @@ func NewCache(interval time.Duration) *Cache {
 	return &cache
 }
 
+// NewDiskCache returns a cache that also keeps its entries in dir. Entries
+// reaped from memory are read back from disk when asked for again.
+func NewDiskCache(interval time.Duration, dir string) *Cache {
+	cache := NewCache(interval)
+	cache.dir = dir
+	return cache
+}
+
 func (cache *Cache) reapLoop(interval time.Duration) {
 	timer := time.NewTicker(interval)
 	for {
@@ -49,6 +64,13 @@ func (cache *Cache) Add(key string, val []byte) {
 		createdAt: time.Now(),
 		val:       val,
 	}
+	if cache.dir != "" {
+		// The disk is only a second tier; failing to write to it still
+		// leaves the entry cached in memory.
+		if err := os.MkdirAll(cache.dir, 0o755); err == nil {
+			os.WriteFile(cache.path(key), val, 0o644)
+		}
+	}
 }
 
 func (cache *Cache) Get(key string) ([]byte, bool) {
@@ -56,8 +78,25 @@ func (cache *Cache) Get(key string) ([]byte, bool) {
 	defer cache.mutex.Unlock()
 
 	entry, ok := cache.store[key]
-	if !ok {
+	if ok {
+		return entry.val, true
+	}
+	if cache.dir == "" {
+		return nil, false
+	}
+	val, err := os.ReadFile(cache.path(key))
+	if err != nil {
 		return nil, false
 	}
-	return entry.val, ok
+	cache.store[key] = cacheEntry{
+		createdAt: time.Now(),
+		val:       val,
+	}
+	return val, true
+}
+
+// path returns the file an entry is kept in on disk.
+func (cache *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(cache.dir, hex.EncodeToString(sum[:]))
 }
@@ -59,3 +59,25 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestDiskCache(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	const waitTime = baseTime + 5*time.Millisecond
+	dir := t.TempDir()
+	cache := NewDiskCache(baseTime, dir)
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(waitTime)
+
+	val, ok := cache.Get("https://example.com")
+	if !ok || string(val) != "testdata" {
+		t.Errorf("expected to find key on disk")
+		return
+	}
+
+	val, ok = NewDiskCache(baseTime, dir).Get("https://example.com")
+	if !ok || string(val) != "testdata" {
+		t.Errorf("expected to find key in a new cache")
+		return
+	}
+}
@@ -0,0 +1,117 @@
+package termimg
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"strings"
+)
+
+// kittyChunkSize is the largest payload the kitty protocol accepts in one
+// escape sequence.
+const kittyChunkSize = 4096
+
+// kitty transmits and displays a PNG using the kitty graphics protocol.
+func kitty(data []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var builder strings.Builder
+	for i := 0; i < len(encoded); i += kittyChunkSize {
+		chunk := encoded[i:min(i+kittyChunkSize, len(encoded))]
+		more := 0
+		if i+kittyChunkSize < len(encoded) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&builder, "\x1b_Gf=100,a=T,m=%d;%s\x1b\\", more, chunk)
+		} else {
+			fmt.Fprintf(&builder, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// iterm displays a PNG using the iTerm2 inline images protocol.
+func iterm(data []byte) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;preserveAspectRatio=1:%s\a\n",
+		len(data), base64.StdEncoding.EncodeToString(data))
+}
+
+// sixel encodes an image in the DEC sixel format, with colours reduced to
+// the 216 colour cube. Transparent pixels are left undrawn.
+func sixel(img image.Image) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]int, width*height)
+	used := map[int]bool{}
+	for y := range height {
+		for x := range width {
+			c := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+			if !opaque(c) {
+				pixels[y*width+x] = -1
+				continue
+			}
+			rgb := color.NRGBAModel.Convert(c).(color.NRGBA)
+			index := 36*cubeIndex(rgb.R) + 6*cubeIndex(rgb.G) + cubeIndex(rgb.B)
+			pixels[y*width+x] = index
+			used[index] = true
+		}
+	}
+	var builder strings.Builder
+	// P2 = 1 leaves pixels that aren't drawn as the terminal background.
+	fmt.Fprintf(&builder, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	colors := []int{}
+	for index := range used {
+		colors = append(colors, index)
+	}
+	slices.Sort(colors)
+	for _, index := range colors {
+		r, g, b := index/36, index/6%6, index%6
+		fmt.Fprintf(&builder, "#%d;2;%d;%d;%d", index,
+			cubeLevels[r]*100/255, cubeLevels[g]*100/255, cubeLevels[b]*100/255)
+	}
+	for band := 0; band < height; band += 6 {
+		for _, index := range colors {
+			row := make([]byte, width)
+			drawn := false
+			for x := range width {
+				bits := 0
+				for r := range 6 {
+					if y := band + r; y < height && pixels[y*width+x] == index {
+						bits |= 1 << r
+						drawn = true
+					}
+				}
+				row[x] = byte(63 + bits)
+			}
+			if !drawn {
+				continue
+			}
+			fmt.Fprintf(&builder, "#%d%s$", index, runLength(row))
+		}
+		builder.WriteString("-")
+	}
+	builder.WriteString("\x1b\\\n")
+	return builder.String()
+}
+
+// runLength compresses repeated sixel characters with the "!n" repeat
+// introducer.
+func runLength(row []byte) string {
+	var builder strings.Builder
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if count := j - i; count > 3 {
+			fmt.Fprintf(&builder, "!%d%c", count, row[i])
+		} else {
+			builder.WriteString(strings.Repeat(string(row[i]), count))
+		}
+		i = j
+	}
+	return builder.String()
+}
@@ -0,0 +1,154 @@
+// Package termimg renders images in the terminal, using an inline image
+// protocol when the terminal supports one and coloured text otherwise.
+package termimg
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// Mode is a way of drawing images in a terminal.
+type Mode int
+
+const (
+	// ASCII draws with plain characters, for terminals without colour.
+	ASCII Mode = iota
+	// ANSI256 draws half blocks in the xterm 256 colour palette.
+	ANSI256
+	// TrueColor draws half blocks in 24-bit colour.
+	TrueColor
+	// Sixel uses the DEC sixel graphics format.
+	Sixel
+	// ITerm uses the iTerm2 inline images protocol.
+	ITerm
+	// Kitty uses the kitty graphics protocol.
+	Kitty
+)
+
+var modeNames = map[Mode]string{
+	ASCII:     "ascii",
+	ANSI256:   "256",
+	TrueColor: "truecolor",
+	Sixel:     "sixel",
+	ITerm:     "iterm",
+	Kitty:     "kitty",
+}
+
+func (mode Mode) String() string {
+	return modeNames[mode]
+}
+
+// ParseMode returns the mode with the given name, as returned by String.
+func ParseMode(name string) (Mode, error) {
+	for mode, modeName := range modeNames {
+		if modeName == name {
+			return mode, nil
+		}
+	}
+	return ASCII, fmt.Errorf("unknown image mode: %s", name)
+}
+
+// Detect picks the best mode the terminal supports, judging by its
+// environment variables as returned by getenv.
+func Detect(getenv func(string) string) Mode {
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+	colorterm := getenv("COLORTERM")
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || program == "ghostty":
+		return Kitty
+	case program == "iTerm.app" || program == "WezTerm":
+		return ITerm
+	case strings.Contains(term, "sixel") || term == "mlterm" || strings.HasPrefix(term, "foot"):
+		return Sixel
+	case colorterm == "truecolor" || colorterm == "24bit":
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return ANSI256
+	default:
+		return ASCII
+	}
+}
+
+// pixelScale is how much images are enlarged for modes that draw pixels
+// rather than characters, since sprites are small.
+const pixelScale = 4
+
+// Render draws the PNG image in data using mode. Images drawn with
+// characters are shrunk to at most maxWidth columns.
+func Render(data []byte, mode Mode, maxWidth int) (string, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("unable to decode image: %w", err)
+	}
+	img = crop(img)
+	switch mode {
+	case Kitty, ITerm:
+		var buffer bytes.Buffer
+		if err := png.Encode(&buffer, scale(img, pixelScale, 1)); err != nil {
+			return "", fmt.Errorf("unable to encode image: %w", err)
+		}
+		if mode == Kitty {
+			return kitty(buffer.Bytes()), nil
+		}
+		return iterm(buffer.Bytes()), nil
+	case Sixel:
+		return sixel(scale(img, pixelScale, 1)), nil
+	}
+	width := img.Bounds().Dx()
+	shrink := max((width+maxWidth-1)/max(maxWidth, 1), 1)
+	img = scale(img, 1, shrink)
+	if mode == ASCII {
+		return ascii(img), nil
+	}
+	return halfBlocks(img, mode == TrueColor), nil
+}
+
+// opaque reports whether a pixel should be drawn.
+func opaque(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a >= 0x8000
+}
+
+// crop trims the transparent border around an image.
+func crop(img image.Image) image.Image {
+	bounds := img.Bounds()
+	visible := image.Rectangle{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if opaque(img.At(x, y)) {
+				visible = visible.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if visible.Empty() {
+		return img
+	}
+	cropped := image.NewNRGBA(image.Rect(0, 0, visible.Dx(), visible.Dy()))
+	for y := range visible.Dy() {
+		for x := range visible.Dx() {
+			cropped.Set(x, y, img.At(visible.Min.X+x, visible.Min.Y+y))
+		}
+	}
+	return cropped
+}
+
+// scale resizes an image by up/down using nearest neighbour sampling, which
+// keeps pixel art sharp.
+func scale(img image.Image, up int, down int) image.Image {
+	if up == down {
+		return img
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx()*up/down, bounds.Dy()*up/down
+	scaled := image.NewNRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	for y := range scaled.Bounds().Dy() {
+		for x := range scaled.Bounds().Dx() {
+			scaled.Set(x, y, img.At(bounds.Min.X+x*down/up, bounds.Min.Y+y*down/up))
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,107 @@
+package termimg
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		env      map[string]string
+		expected Mode
+	}{
+		{env: map[string]string{"TERM": "xterm-kitty"}, expected: Kitty},
+		{env: map[string]string{"TERM_PROGRAM": "iTerm.app", "COLORTERM": "truecolor"}, expected: ITerm},
+		{env: map[string]string{"TERM": "foot"}, expected: Sixel},
+		{env: map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, expected: TrueColor},
+		{env: map[string]string{"TERM": "xterm-256color"}, expected: ANSI256},
+		{env: map[string]string{"TERM": "dumb"}, expected: ASCII},
+	}
+	for _, c := range cases {
+		actual := Detect(func(name string) string { return c.env[name] })
+		if actual != c.expected {
+			t.Errorf("%v: [Expected, Received]: [%v, %v]", c.env, c.expected, actual)
+		}
+	}
+}
+
+func TestXterm256(t *testing.T) {
+	cases := []struct {
+		r, g, b  uint8
+		expected int
+	}{
+		{r: 0, g: 0, b: 0, expected: 16},
+		{r: 255, g: 255, b: 255, expected: 231},
+		{r: 255, g: 0, b: 0, expected: 196},
+		{r: 128, g: 128, b: 128, expected: 244},
+	}
+	for _, c := range cases {
+		if actual := xterm256(c.r, c.g, c.b); actual != c.expected {
+			t.Errorf("(%d, %d, %d): [Expected, Received]: [%d, %d]", c.r, c.g, c.b, c.expected, actual)
+		}
+	}
+}
+
+// testPNG is a 4x4 image with a transparent border around a red pixel above
+// a blue one.
+func testPNG(t *testing.T) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 1, color.NRGBA{R: 255, A: 255})
+	img.Set(1, 2, color.NRGBA{B: 255, A: 255})
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		mode     Mode
+		expected string
+	}{
+		{mode: TrueColor, expected: "\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀\x1b[0m\n"},
+		{mode: ANSI256, expected: "\x1b[38;5;196m\x1b[48;5;21m▀\x1b[0m\n"},
+		{mode: ASCII, expected: ":\n"},
+	}
+	for _, c := range cases {
+		actual, err := Render(testPNG(t), c.mode, 80)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != c.expected {
+			t.Errorf("%v: [Expected, Received]: [%q, %q]", c.mode, c.expected, actual)
+		}
+	}
+}
+
+func TestRenderProtocols(t *testing.T) {
+	cases := []struct {
+		mode   Mode
+		prefix string
+		suffix string
+	}{
+		{mode: Kitty, prefix: "\x1b_Gf=100,a=T,m=0;", suffix: "\x1b\\\n"},
+		{mode: ITerm, prefix: "\x1b]1337;File=inline=1;", suffix: "\a\n"},
+		{mode: Sixel, prefix: "\x1bP0;1;0q\"1;1;4;8#5;2;0;0;100#180;2;100;0;0", suffix: "\x1b\\\n"},
+	}
+	for _, c := range cases {
+		actual, err := Render(testPNG(t), c.mode, 80)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(actual, c.prefix) || !strings.HasSuffix(actual, c.suffix) {
+			t.Errorf("%v: unexpected output %q", c.mode, actual)
+		}
+	}
+}
+
+func TestRunLength(t *testing.T) {
+	if actual := runLength([]byte("??????~~A")); actual != "!6?~~A" {
+		t.Errorf("[Expected, Received]: [%q, %q]", "!6?~~A", actual)
+	}
+}
@@ -0,0 +1,120 @@
+package termimg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// halfBlocks draws two pixels per character cell, using the upper half block
+// coloured with the top pixel over a background of the bottom one.
+func halfBlocks(img image.Image, trueColor bool) string {
+	bounds := img.Bounds()
+	var builder strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top := img.At(x, y)
+			bottom := color.Color(color.Transparent)
+			if y+1 < bounds.Max.Y {
+				bottom = img.At(x, y+1)
+			}
+			switch {
+			case opaque(top) && opaque(bottom):
+				builder.WriteString(sgr(38, top, trueColor) + sgr(48, bottom, trueColor) + "▀")
+			case opaque(top):
+				builder.WriteString("\x1b[0m" + sgr(38, top, trueColor) + "▀")
+			case opaque(bottom):
+				builder.WriteString("\x1b[0m" + sgr(38, bottom, trueColor) + "▄")
+			default:
+				builder.WriteString("\x1b[0m ")
+			}
+		}
+		builder.WriteString("\x1b[0m\n")
+	}
+	return builder.String()
+}
+
+// sgr returns the escape sequence setting the foreground (38) or background
+// (48) colour.
+func sgr(layer int, c color.Color, trueColor bool) string {
+	rgb := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if trueColor {
+		return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", layer, rgb.R, rgb.G, rgb.B)
+	}
+	return fmt.Sprintf("\x1b[%d;5;%dm", layer, xterm256(rgb.R, rgb.G, rgb.B))
+}
+
+// cubeLevels are the channel intensities of the xterm 6x6x6 colour cube.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// cubeIndex returns the index of the cube level nearest to value.
+func cubeIndex(value uint8) int {
+	best := 0
+	for i, level := range cubeLevels {
+		if abs(int(value)-level) < abs(int(value)-cubeLevels[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// xterm256 returns the xterm 256 colour palette entry nearest to a colour,
+// from the colour cube or the greyscale ramp.
+func xterm256(r, g, b uint8) int {
+	ri, gi, bi := cubeIndex(r), cubeIndex(g), cubeIndex(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDistance := distance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+	average := (int(r) + int(g) + int(b)) / 3
+	grey := min(max((average-8+5)/10, 0), 23)
+	greyLevel := 8 + 10*grey
+	if distance(r, g, b, greyLevel, greyLevel, greyLevel) < cubeDistance {
+		return 232 + grey
+	}
+	return cube
+}
+
+func distance(r, g, b uint8, r2, g2, b2 int) int {
+	dr, dg, db := int(r)-r2, int(g)-g2, int(b)-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// asciiRamp orders characters from least to most ink.
+const asciiRamp = " .:-=+*#%@"
+
+// ascii draws two pixels per character, choosing denser characters for
+// brighter pixels, which suits terminals with dark backgrounds.
+func ascii(img image.Image) string {
+	bounds := img.Bounds()
+	var builder strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		line := ""
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			total, count := 0, 0
+			for _, pixel := range []image.Point{{x, y}, {x, y + 1}} {
+				if pixel.Y >= bounds.Max.Y || !opaque(img.At(pixel.X, pixel.Y)) {
+					continue
+				}
+				grey := color.GrayModel.Convert(img.At(pixel.X, pixel.Y)).(color.Gray)
+				total += int(grey.Y)
+				count += 1
+			}
+			if count == 0 {
+				line += " "
+				continue
+			}
+			// Anything visible gets at least the lightest mark.
+			index := 1 + (total/count)*(len(asciiRamp)-2)/255
+			line += string(asciiRamp[index])
+		}
+		builder.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return builder.String()
+}
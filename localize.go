@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// languages are the language codes the API has translations for.
+var languages = []string{
+	"ja-hrkt", "roomaji", "ko", "zh-hant", "fr", "de", "es", "it", "en", "cs", "ja", "zh-hans", "pt-br",
+}
+
+func parseLanguage(value string) (string, error) {
+	if !slices.Contains(languages, strings.ToLower(value)) {
+		return "", fmt.Errorf("expecting a language, one of %v, got %q", languages, value)
+	}
+	return strings.ToLower(value), nil
+}
+
+// localName returns the name from names in the configured language, or
+// fallback, the API name, if there is no language set or no translation.
+func localName(config *Config, names []pokeapi.Name, fallback string) string {
+	if config.Settings.Language == "" {
+		return fallback
+	}
+	if name := pokeapi.Localized(names, config.Settings.Language); name != "" {
+		return name
+	}
+	return fallback
+}
+
+// nameMatches reports whether input, as typed by the trainer, names the
+// resource with the API name and translations given.
+func nameMatches(config *Config, names []pokeapi.Name, apiName string, input string) bool {
+	return input == apiName || strings.EqualFold(localName(config, names, apiName), input)
+}
+
+func (pokemon *Pokemon) displayName(config *Config) string {
+	return localName(config, pokemon.Species.Names, pokemon.Name)
+}
+
+func (wild *wildPokemon) displayName(config *Config) string {
+	return localName(config, wild.Species.Names, wild.Pokemon.Name)
+}
+
+// localSpeciesName returns the name of the species in the configured
+// language. Translations need fetching, so the API name is used if that
+// fails.
+func localSpeciesName(config *Config, speciesName string) string {
+	if config.Settings.Language == "" {
+		return speciesName
+	}
+	species, err := pokeapi.GetPokemonSpecies(speciesName, config.Cache)
+	if err != nil {
+		return speciesName
+	}
+	return localName(config, species.Names, speciesName)
+}
+
+// localPokemonName returns the name of the Pokemon, as opposed to the
+// species, in the configured language.
+func localPokemonName(config *Config, pokemonName string) string {
+	if config.Settings.Language == "" {
+		return pokemonName
+	}
+	pokemon, err := pokeapi.GetPokemon(pokemonName, config.Cache)
+	if err != nil {
+		return pokemonName
+	}
+	species, err := pokeapi.GetPokemonSpecies(pokemon.Species.Name, config.Cache)
+	if err != nil {
+		return pokemonName
+	}
+	return localName(config, species.Names, pokemonName)
+}
+
+// localLocationName returns the name of the location in the configured
+// language.
+func localLocationName(config *Config, locationName string) string {
+	if config.Settings.Language == "" {
+		return locationName
+	}
+	location, err := pokeapi.GetLocation(locationName, config.Cache)
+	if err != nil {
+		return locationName
+	}
+	return localName(config, location.Names, locationName)
+}
+
+// indexWorkers is how many species are fetched at once when building the
+// index of species names in a language.
+const indexWorkers = 8
+
+// localSpeciesIndex returns the species' names in the configured language,
+// lowercased, mapped to their API names. Building it fetches every species
+// once; it is then kept in config.IndexDir.
+func localSpeciesIndex(config *Config) (map[string]string, error) {
+	language := config.Settings.Language
+	if index, ok := config.LocalNameIndex[language]; ok {
+		return index, nil
+	}
+	if config.LocalNameIndex == nil {
+		config.LocalNameIndex = map[string]map[string]string{}
+	}
+	path := ""
+	if config.IndexDir != "" {
+		path = filepath.Join(config.IndexDir, "pokemon-species."+language+".json")
+		if data, err := os.ReadFile(path); err == nil {
+			index := map[string]string{}
+			if json.Unmarshal(data, &index) == nil {
+				config.LocalNameIndex[language] = index
+				return index, nil
+			}
+		}
+	}
+	names, err := nameIndex(config, "pokemon-species")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Indexing Pokemon names in %s, which is only done once...\n", language)
+	index := map[string]string{}
+	var mutex sync.Mutex
+	var group sync.WaitGroup
+	queue := make(chan string)
+	for range indexWorkers {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for name := range queue {
+				species, err := pokeapi.GetPokemonSpecies(name, config.Cache)
+				if err != nil {
+					continue
+				}
+				if local := pokeapi.Localized(species.Names, language); local != "" {
+					mutex.Lock()
+					index[strings.ToLower(local)] = name
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	for _, name := range names {
+		queue <- name
+	}
+	close(queue)
+	group.Wait()
+	config.LocalNameIndex[language] = index
+	if path != "" {
+		data, err := json.Marshal(index)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshall name index: %w", err)
+		}
+		if err := os.MkdirAll(config.IndexDir, 0o755); err != nil {
+			return nil, fmt.Errorf("unable to create index directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("unable to write name index: %w", err)
+		}
+	}
+	return index, nil
+}
+
+// speciesAPIName returns the API name of the species input names, which may
+// be in the configured language.
+func speciesAPIName(config *Config, input string) (string, error) {
+	if config.Settings.Language == "" {
+		return input, nil
+	}
+	names, err := nameIndex(config, "pokemon-species")
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(names, input) {
+		return input, nil
+	}
+	index, err := localSpeciesIndex(config)
+	if err != nil {
+		return "", err
+	}
+	if name, ok := index[strings.ToLower(input)]; ok {
+		return name, nil
+	}
+	return input, nil
+}
+
+// localAreaName returns the name of the location area in the configured
+// language.
+func localAreaName(config *Config, areaName string) string {
+	if config.Settings.Language == "" {
+		return areaName
+	}
+	area, err := pokeapi.GetLocationArea(areaName, config.Cache)
+	if err != nil {
+		return areaName
+	}
+	return localName(config, area.Names, areaName)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestLocalName(t *testing.T) {
+	names := []pokeapi.Name{
+		{Name: "ピカチュウ", Language: pokeapi.Resource{Name: "ja"}},
+		{Name: "皮卡丘", Language: pokeapi.Resource{Name: "zh-Hans"}},
+		{Name: "Pikachu", Language: pokeapi.Resource{Name: "en"}},
+	}
+	cases := []struct {
+		language string
+		expected string
+	}{
+		{language: "", expected: "pikachu"},
+		{language: "ja", expected: "ピカチュウ"},
+		{language: "zh-hans", expected: "皮卡丘"},
+		{language: "ko", expected: "pikachu"},
+	}
+	config := testConfig(1)
+	for _, c := range cases {
+		config.Settings.Language = c.language
+		if actual := localName(config, names, "pikachu"); actual != c.expected {
+			t.Errorf("%s: [Expected, Received]: ['%s', '%s']", c.language, c.expected, actual)
+		}
+	}
+}
+
+func TestNameMatches(t *testing.T) {
+	names := []pokeapi.Name{{Name: "Glumanda", Language: pokeapi.Resource{Name: "de"}}}
+	config := testConfig(1)
+	config.Settings.Language = "de"
+	cases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "charmander", expected: true},
+		{input: "glumanda", expected: true},
+		{input: "glumand", expected: false},
+	}
+	for _, c := range cases {
+		if actual := nameMatches(config, names, "charmander", c.input); actual != c.expected {
+			t.Errorf("%s: [Expected, Received]: [%v, %v]", c.input, c.expected, actual)
+		}
+	}
+}
+
+func TestSpeciesAPIName(t *testing.T) {
+	config := testConfig(1)
+	config.Settings.Language = "fr"
+	config.NameIndex = map[string][]string{"pokemon-species": {"pikachu", "bulbasaur"}}
+	config.LocalNameIndex = map[string]map[string]string{"fr": {"bulbizarre": "bulbasaur"}}
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "pikachu", expected: "pikachu"},
+		{input: "bulbizarre", expected: "bulbasaur"},
+		{input: "Bulbizarre", expected: "bulbasaur"},
+		{input: "missingno", expected: "missingno"},
+	}
+	for _, c := range cases {
+		actual, err := speciesAPIName(config, c.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != c.expected {
+			t.Errorf("[Expected, Received]: [%q, %q]", c.expected, actual)
+		}
+	}
+}
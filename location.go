@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// currentArea returns the location area named by args, or the trainer's
+// current area if args is empty. It returns an error with a hint if neither
+// is available.
+func currentArea(config *Config, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if config.Location == "" {
+		return "", errors.New("You aren't anywhere yet. Use travel <location-area> to go somewhere.")
+	}
+	return config.Location, nil
+}
+
+// isCave reports whether a location area is underground, for the Dusk Ball.
+func isCave(area string) bool {
+	for _, word := range []string{"cave", "cavern", "tunnel", "mt-", "mount-", "grotto", "chamber", "ruins"} {
+		if strings.Contains(area, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func commandTravel(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("travel <location-area>")
+	}
+	if config.Wild != nil {
+		return errors.New("You can't leave while a wild " + config.Wild.displayName(config) + " is in front of you!")
+	}
+	area, err := pokeapi.GetLocationArea(args[1], config.Cache)
+	if err != nil {
+		return err
+	}
+	config.Location = area.Name
+	fmt.Println("You travelled to " + localName(config, area.Names, area.Name) + ".")
+	return nil
+}
+
+func commandHere(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usage("here [--json]")
+	}
+	areaName, err := currentArea(config, nil)
+	if err != nil {
+		return err
+	}
+	area, err := pokeapi.GetLocationArea(areaName, config.Cache)
+	if err != nil {
+		return err
+	}
+	version := encounterVersion(area, config.Settings.Version)
+	methods := encounterMethods(area, version)
+	if jsonOutput(config, flags) {
+		return printJSON(areaSummary{
+			Area:                area.Name,
+			DisplayName:         localName(config, area.Names, area.Name),
+			Location:            area.Location.Name,
+			LocationDisplayName: localLocationName(config, area.Location.Name),
+			Version:             version,
+			Methods:             methods,
+			Wild:                summarizeWild(config, config.Wild),
+		})
+	}
+	fmt.Println("You are in " + localName(config, area.Names, area.Name) + ", part of " + localLocationName(config, area.Location.Name) + ".")
+	if len(methods) == 0 {
+		fmt.Println("There are no wild Pokemon here.")
+	} else {
+		fmt.Printf("Wild Pokemon can be found here by: %s (%s)\n", strings.Join(methods, ", "), version)
+	}
+	if config.Wild != nil {
+		fmt.Println("A wild " + config.Wild.describe(config) + " is in front of you.")
+	}
+	return nil
+}
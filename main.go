@@ -1,5 +1,98 @@
 package main
 
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// Exit codes of a command run from the command line.
+const (
+	exitSuccess = 0
+	// exitFailure is used when the command returns an error.
+	exitFailure = 1
+	// exitUsage is used for an unknown command, a command given the wrong
+	// arguments, or bad global flags.
+	exitUsage = 2
+)
+
 func main() {
-	repl()
+	seed := flag.Uint64("seed", rand.Uint64(), "seed for the random number generator")
+	profile := flag.String("profile", "", "profile to play as, each with its own save and history")
+	offline := flag.Bool("offline", false, "only use Pokemon data cached by earlier sessions")
+	output := flag.String("output", "text", "format of errors and of the output of map, mapb, pokedex, inspect, party, box, bag and here, text or json")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: pokedexcli [flags] [command [arguments]]")
+		fmt.Fprintln(flag.CommandLine.Output(), "       pokedexcli [flags] run <script>")
+		fmt.Fprintln(flag.CommandLine.Output(), "Without a command, the interactive Pokedex is started.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if !slices.Contains(outputFormats, *output) {
+		fmt.Fprintf(os.Stderr, "Unknown output format %q, expecting one of %v\n", *output, outputFormats)
+		os.Exit(exitUsage)
+	}
+	if strings.ContainsAny(*profile, `/\`) || *profile == "." || *profile == ".." {
+		fmt.Fprintf(os.Stderr, "Invalid profile name %q\n", *profile)
+		os.Exit(exitUsage)
+	}
+	pokeapi.Offline = *offline
+
+	commands = registerCommands()
+	config, err := newConfig(*seed, *profile)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(exitFailure)
+	}
+	config.Output = *output
+	if flag.NArg() == 0 {
+		repl(config)
+		return
+	}
+	os.Exit(runCommand(config, flag.Args()))
+}
+
+// runCommand runs a single command given on the command line, saving the
+// game afterwards, and returns the exit code.
+func runCommand(config *Config, args []string) int {
+	input := strings.Join(args, " ")
+	if strings.TrimSpace(input) == "" {
+		flag.Usage()
+		return exitUsage
+	}
+	// On the command line, run is given a script to run. In the Pokedex it
+	// runs from the wild Pokemon, and scripts are run with source.
+	var err error
+	if len(args) == 2 && strings.EqualFold(args[0], "run") {
+		err = runScript(config, args[1])
+	} else {
+		err = execute(config, input)
+	}
+	code := exitSuccess
+	if err != nil {
+		printError(config, err)
+		code = exitCode(err)
+	}
+	// A command can change the game before it fails, so it's saved either way.
+	if err := saveGame(config); err != nil {
+		fmt.Println(err.Error())
+		return exitFailure
+	}
+	return code
+}
+
+// exitCode returns the exit code for an error returned by a command.
+func exitCode(err error) int {
+	var unknown *unknownCommandError
+	var usage *usageError
+	if errors.As(err, &unknown) || errors.As(err, &usage) {
+		return exitUsage
+	}
+	return exitFailure
 }
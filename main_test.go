@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunCommand(t *testing.T) {
+	commands = registerCommands()
+	addTestCommand(t, "fail", func(*Config, []string) error {
+		return errors.New("failed")
+	})
+	cases := []struct {
+		args     []string
+		expected int
+	}{
+		{args: []string{"bag"}, expected: exitSuccess},
+		{args: []string{"BAG", "--json"}, expected: exitSuccess},
+		{args: []string{"fail"}, expected: exitFailure},
+		{args: []string{"catch", "pikachu"}, expected: exitFailure},
+		{args: []string{"inspect", "bulbasaur", "--json"}, expected: exitFailure},
+		{args: []string{"deposit", "bulbasaur"}, expected: exitFailure},
+		{args: []string{"evolve", "bulbasaur", "--trade"}, expected: exitFailure},
+		{args: []string{"withdraw", "9", "1"}, expected: exitFailure},
+		{args: []string{"catch"}, expected: exitUsage},
+		{args: []string{"box", "99"}, expected: exitUsage},
+		{args: []string{"bogus"}, expected: exitUsage},
+		{args: []string{" "}, expected: exitUsage},
+	}
+	for _, c := range cases {
+		if actual := runCommand(testConfig(1), c.args); actual != c.expected {
+			t.Errorf("%v: [Expected, Received]: [%d, %d]", c.args, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// maxKnownMoves is the number of moves a Pokemon can know at once.
+const maxKnownMoves = 4
+
+// latestVersionGroup returns the most recent version group in which pokemon
+// learns moves by levelling up.
+func latestVersionGroup(pokemon pokeapi.Pokemon) pokeapi.Resource {
+	latest := pokeapi.Resource{}
+	for _, move := range pokemon.Moves {
+		for _, details := range move.VersionGroupDetails {
+			if details.MoveLearnMethod.Name == "level-up" && details.VersionGroup.ID() > latest.ID() {
+				latest = details.VersionGroup
+			}
+		}
+	}
+	return latest
+}
+
+type learnedMove struct {
+	name  string
+	level int
+}
+
+// learnset returns the moves pokemon learns in a version group by method,
+// ordered by the level they are learned at.
+func learnset(pokemon pokeapi.Pokemon, group string, method string) []learnedMove {
+	moves := []learnedMove{}
+	for _, move := range pokemon.Moves {
+		for _, details := range move.VersionGroupDetails {
+			if details.VersionGroup.Name == group && details.MoveLearnMethod.Name == method {
+				moves = append(moves, learnedMove{name: move.Move.Name, level: details.LevelLearnedAt})
+			}
+		}
+	}
+	slices.SortStableFunc(moves, func(a, b learnedMove) int {
+		return a.level - b.level
+	})
+	return moves
+}
+
+// knownMoves returns the moves a Pokemon knows at level, which, as for wild
+// Pokemon in the games, are the last four it learned by levelling up.
+func knownMoves(pokemon pokeapi.Pokemon, level int) []string {
+	moves := []learnedMove{}
+	for _, move := range learnset(pokemon, latestVersionGroup(pokemon).Name, "level-up") {
+		if move.level <= level && !slices.ContainsFunc(moves, func(known learnedMove) bool {
+			return known.name == move.name
+		}) {
+			moves = append(moves, move)
+		}
+	}
+	names := []string{}
+	for _, move := range moves[max(len(moves)-maxKnownMoves, 0):] {
+		names = append(names, move.name)
+	}
+	return names
+}
+
+// moveEffect returns the short English description of the move's effect.
+func moveEffect(move pokeapi.Move) string {
+	for _, entry := range move.EffectEntries {
+		if entry.Language.Name != "en" {
+			continue
+		}
+		effect := entry.ShortEffect
+		if move.EffectChance != nil {
+			effect = strings.ReplaceAll(effect, "$effect_chance", strconv.Itoa(*move.EffectChance))
+		}
+		return effect
+	}
+	return ""
+}
+
+// formatOptional formats a move value the API leaves null, such as the power
+// of a status move.
+func formatOptional(value *int) string {
+	if value == nil {
+		return "-"
+	}
+	return strconv.Itoa(*value)
+}
+
+func commandMoves(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:], "version-group", "method")
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usage("moves <pokemon> [--version-group <group>] [--method <method>]")
+	}
+	pokemon, err := pokeapi.GetPokemon(positional[0], config.Cache)
+	if err != nil {
+		return err
+	}
+	group, ok := flags["version-group"]
+	if !ok {
+		group = latestVersionGroup(pokemon).Name
+	}
+	method, ok := flags["method"]
+	if !ok {
+		method = "level-up"
+	}
+	moves := learnset(pokemon, group, method)
+	if len(moves) == 0 {
+		fmt.Printf("%s learns no moves by %s in %s.\n", localPokemonName(config, pokemon.Name), method, group)
+		return nil
+	}
+	fmt.Printf("Moves %s learns by %s in %s:\n", localPokemonName(config, pokemon.Name), method, group)
+	for _, move := range moves {
+		if method == "level-up" {
+			fmt.Printf("  Lv. %3d %s\n", move.level, move.name)
+		} else {
+			fmt.Println("  -", move.name)
+		}
+	}
+	return nil
+}
+
+func commandMove(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("move <name>")
+	}
+	move, err := pokeapi.GetMove(args[1], config.Cache)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Name:", move.Name)
+	fmt.Println("Type:", move.Type.Name)
+	fmt.Println("Category:", move.DamageClass.Name)
+	fmt.Println("Power:", formatOptional(move.Power))
+	fmt.Println("Accuracy:", formatOptional(move.Accuracy))
+	fmt.Println("PP:", move.PP)
+	if move.Priority != 0 {
+		fmt.Println("Priority:", move.Priority)
+	}
+	if effect := moveEffect(move); effect != "" {
+		fmt.Println("Effect:", effect)
+	}
+	return nil
+}
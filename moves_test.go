@@ -0,0 +1,73 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func learn(move string, group string, groupID string, level int) pokeapi.PokemonMove {
+	return pokeapi.PokemonMove{
+		Move: pokeapi.Resource{Name: move},
+		VersionGroupDetails: []pokeapi.PokemonMoveVersion{{
+			MoveLearnMethod: pokeapi.Resource{Name: "level-up"},
+			VersionGroup:    pokeapi.Resource{Name: group, URL: "https://pokeapi.co/api/v2/version-group/" + groupID + "/"},
+			LevelLearnedAt:  level,
+		}},
+	}
+}
+
+func TestKnownMoves(t *testing.T) {
+	pokemon := pokeapi.Pokemon{
+		Moves: []pokeapi.PokemonMove{
+			learn("tackle", "scarlet-violet", "25", 1),
+			learn("growl", "scarlet-violet", "25", 1),
+			learn("vine-whip", "scarlet-violet", "25", 3),
+			learn("growth", "scarlet-violet", "25", 6),
+			learn("leech-seed", "scarlet-violet", "25", 9),
+			learn("razor-leaf", "scarlet-violet", "25", 12),
+			learn("poison-powder", "red-blue", "1", 2),
+		},
+	}
+	cases := []struct {
+		level    int
+		expected []string
+	}{
+		{level: 1, expected: []string{"tackle", "growl"}},
+		{level: 9, expected: []string{"growl", "vine-whip", "growth", "leech-seed"}},
+		{level: 50, expected: []string{"vine-whip", "growth", "leech-seed", "razor-leaf"}},
+	}
+	for _, c := range cases {
+		if actual := knownMoves(pokemon, c.level); !slices.Equal(actual, c.expected) {
+			t.Errorf("level %d: [Expected, Received]: [%v, %v]", c.level, c.expected, actual)
+		}
+	}
+}
+
+func TestLearnset(t *testing.T) {
+	machine := learn("toxic", "red-blue", "1", 0)
+	machine.VersionGroupDetails[0].MoveLearnMethod.Name = "machine"
+	pokemon := pokeapi.Pokemon{
+		Moves: []pokeapi.PokemonMove{
+			learn("razor-leaf", "red-blue", "1", 27),
+			learn("leech-seed", "red-blue", "1", 7),
+			learn("vine-whip", "scarlet-violet", "25", 3),
+			machine,
+		},
+	}
+	cases := []struct {
+		group    string
+		method   string
+		expected []learnedMove
+	}{
+		{group: "red-blue", method: "level-up", expected: []learnedMove{{"leech-seed", 7}, {"razor-leaf", 27}}},
+		{group: "red-blue", method: "machine", expected: []learnedMove{{"toxic", 0}}},
+		{group: "scarlet-violet", method: "machine", expected: []learnedMove{}},
+	}
+	for _, c := range cases {
+		if actual := learnset(pokemon, c.group, c.method); !slices.Equal(actual, c.expected) {
+			t.Errorf("%s by %s: [Expected, Received]: [%v, %v]", c.group, c.method, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jthughes/pokedexcli/internal/capture"
+)
+
+// outputFormats lists the formats commands can write their output in. Only
+// the listing commands, and errors, support JSON; the others always write
+// text.
+var outputFormats = []string{"text", "json"}
+
+// jsonOutput reports whether a command should write JSON, as asked for with
+// its --json flag or the global --output flag.
+func jsonOutput(config *Config, flags map[string]string) bool {
+	return flags["json"] == "true" || config.Output == "json"
+}
+
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshall output: %w", err)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
+
+type statSummary struct {
+	Value int `json:"value"`
+	Base  int `json:"base"`
+	IV    int `json:"iv"`
+	EV    int `json:"ev"`
+}
+
+// pokemonSummary is one of the trainer's Pokemon as written in JSON.
+type pokemonSummary struct {
+	Name          string                 `json:"name"`
+	DisplayName   string                 `json:"display_name"`
+	Species       string                 `json:"species"`
+	Genus         string                 `json:"genus,omitempty"`
+	Gender        string                 `json:"gender,omitempty"`
+	Shiny         bool                   `json:"shiny"`
+	Height        int                    `json:"height"`
+	Weight        int                    `json:"weight"`
+	Level         int                    `json:"level"`
+	Experience    int                    `json:"experience"`
+	HP            int                    `json:"hp"`
+	MaxHP         int                    `json:"max_hp"`
+	Status        capture.Status         `json:"status,omitempty"`
+	Friendship    int                    `json:"friendship"`
+	Ball          string                 `json:"ball"`
+	Nature        string                 `json:"nature"`
+	Ability       string                 `json:"ability,omitempty"`
+	HiddenAbility bool                   `json:"hidden_ability"`
+	Stats         map[string]statSummary `json:"stats"`
+	Moves         []string               `json:"moves"`
+	Types         []string               `json:"types"`
+}
+
+func summarizePokemon(config *Config, pokemon Pokemon) pokemonSummary {
+	summary := pokemonSummary{
+		Name:          pokemon.Name,
+		DisplayName:   pokemon.displayName(config),
+		Species:       pokemon.Species.Name,
+		Genus:         genus(config, pokemon.Species),
+		Gender:        pokemon.Gender,
+		Shiny:         pokemon.Shiny,
+		Height:        pokemon.Height,
+		Weight:        pokemon.Weight,
+		Level:         pokemon.Level,
+		Experience:    pokemon.Experience,
+		HP:            pokemon.CurrentHP,
+		MaxHP:         pokemon.maxHP(),
+		Status:        pokemon.Status,
+		Friendship:    pokemon.Friendship,
+		Ball:          pokemon.Ball,
+		Nature:        pokemon.Nature.Name,
+		Ability:       pokemon.Ability.Ability.Name,
+		HiddenAbility: pokemon.Ability.IsHidden,
+		Stats:         map[string]statSummary{},
+		Moves:         pokemon.KnownMoves,
+		Types:         []string{},
+	}
+	for _, name := range statNames {
+		summary.Stats[name] = statSummary{
+			Value: pokemon.stat(name),
+			Base:  baseStat(pokemon.Pokemon, name),
+			IV:    pokemon.IVs[name],
+			EV:    pokemon.EVs[name],
+		}
+	}
+	for _, pokemonType := range pokemon.Types {
+		summary.Types = append(summary.Types, pokemonType.Type.Name)
+	}
+	return summary
+}
+
+// errorSummary is an error returned by a command as written in JSON.
+type errorSummary struct {
+	Error       string   `json:"error"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// pokedexEntrySummary is a species in the Pokedex as written in JSON. Number
+// is its number in a regional Pokedex.
+type pokedexEntrySummary struct {
+	Number      int    `json:"number,omitempty"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Owned       bool   `json:"owned"`
+	Caught      int    `json:"caught"`
+	Shiny       int    `json:"shiny"`
+}
+
+func summarizePokedexEntry(config *Config, number int, name string) pokedexEntrySummary {
+	entry, ok := config.Pokedex[name]
+	return pokedexEntrySummary{
+		Number:      number,
+		Name:        name,
+		DisplayName: localSpeciesName(config, name),
+		Owned:       ok,
+		Caught:      entry.Caught,
+		Shiny:       entry.Shiny,
+	}
+}
+
+// boxSummary is a PC box as written in JSON.
+type boxSummary struct {
+	Box     int              `json:"box"`
+	Pokemon []pokemonSummary `json:"pokemon"`
+}
+
+func summarizeBox(config *Config, n int) boxSummary {
+	summary := boxSummary{Box: n, Pokemon: []pokemonSummary{}}
+	for _, pokemon := range config.Boxes[n-1] {
+		summary.Pokemon = append(summary.Pokemon, summarizePokemon(config, pokemon))
+	}
+	return summary
+}
+
+// wildSummary is the wild Pokemon being faced as written in JSON.
+type wildSummary struct {
+	Name        string         `json:"name"`
+	DisplayName string         `json:"display_name"`
+	Gender      string         `json:"gender,omitempty"`
+	Shiny       bool           `json:"shiny"`
+	Level       int            `json:"level"`
+	HP          int            `json:"hp"`
+	MaxHP       int            `json:"max_hp"`
+	Status      capture.Status `json:"status,omitempty"`
+}
+
+func summarizeWild(config *Config, wild *wildPokemon) *wildSummary {
+	if wild == nil {
+		return nil
+	}
+	return &wildSummary{
+		Name:        wild.Pokemon.Name,
+		DisplayName: wild.displayName(config),
+		Gender:      wild.Gender,
+		Shiny:       wild.Shiny,
+		Level:       wild.Level,
+		HP:          wild.CurrentHP,
+		MaxHP:       wild.maxHP(),
+		Status:      wild.Status,
+	}
+}
+
+// areaSummary is the trainer's location area as written in JSON.
+type areaSummary struct {
+	Area                string       `json:"area"`
+	DisplayName         string       `json:"display_name"`
+	Location            string       `json:"location"`
+	LocationDisplayName string       `json:"location_display_name"`
+	Version             string       `json:"version,omitempty"`
+	Methods             []string     `json:"methods"`
+	Wild                *wildSummary `json:"wild,omitempty"`
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestSummarizePokedexEntry(t *testing.T) {
+	config := testConfig(1)
+	config.Pokedex["pikachu"] = PokedexEntry{Caught: 2, Shiny: 1}
+	config.Pokedex["raichu"] = PokedexEntry{}
+	cases := []struct {
+		name     string
+		expected pokedexEntrySummary
+	}{
+		{name: "pikachu", expected: pokedexEntrySummary{Number: 25, Name: "pikachu", DisplayName: "pikachu", Owned: true, Caught: 2, Shiny: 1}},
+		{name: "raichu", expected: pokedexEntrySummary{Number: 25, Name: "raichu", DisplayName: "raichu", Owned: true}},
+		{name: "mew", expected: pokedexEntrySummary{Number: 25, Name: "mew", DisplayName: "mew"}},
+	}
+	for _, c := range cases {
+		if actual := summarizePokedexEntry(config, 25, c.name); actual != c.expected {
+			t.Errorf("%s: [Expected, Received]: [%+v, %+v]", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestSummarizeBox(t *testing.T) {
+	config := testConfig(1)
+	config.Boxes[1] = []Pokemon{{Pokemon: pokeapi.Pokemon{Name: "raichu"}, Level: 30}}
+	empty := summarizeBox(config, 1)
+	if empty.Box != 1 || empty.Pokemon == nil || len(empty.Pokemon) != 0 {
+		t.Errorf("expected an empty box 1: %+v", empty)
+	}
+	box := summarizeBox(config, 2)
+	if box.Box != 2 || len(box.Pokemon) != 1 || box.Pokemon[0].Name != "raichu" || box.Pokemon[0].Level != 30 {
+		t.Errorf("expected raichu in box 2: %+v", box)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/capture"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// catchContext describes the circumstances of a throw. Fields that are not
+// known yet are left at their zero value, which never triggers a bonus.
+type catchContext struct {
+	pokemon pokeapi.Pokemon
+	species pokeapi.PokemonSpecies
+	pokedex map[string]PokedexEntry
+	// turn counts the throws made at this Pokemon, starting from 1.
+	turn int
+	now  time.Time
+	// level is the wild Pokemon's level and leadLevel that of the trainer's
+	// leading Pokemon.
+	level     int
+	leadLevel int
+	// leadSpecies and leadGender describe the trainer's leading Pokemon, and
+	// gender the wild one, for the Love Ball.
+	leadSpecies string
+	leadGender  string
+	gender      string
+	// method is the encounter method, such as "walk", "surf" or "old-rod".
+	method string
+	cave   bool
+}
+
+func (c catchContext) hasType(names ...string) bool {
+	for _, pokemonType := range c.pokemon.Types {
+		if slices.Contains(names, pokemonType.Type.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c catchContext) night() bool {
+	return timeOfDay(c.now) == "night"
+}
+
+type pokeball struct {
+	// modifier multiplies the species' catch rate.
+	modifier func(c catchContext) float64
+	// rateBonus, when set, is added to the species' catch rate before the
+	// modifier is applied.
+	rateBonus func(c catchContext) int
+	onCatch   func(config *Config, pokemon *Pokemon)
+}
+
+func flat(modifier float64) func(catchContext) float64 {
+	return func(catchContext) float64 {
+		return modifier
+	}
+}
+
+// when returns modifier if condition holds for the throw, and 1 otherwise.
+func when(modifier float64, condition func(c catchContext) bool) func(catchContext) float64 {
+	return func(c catchContext) float64 {
+		if condition(c) {
+			return modifier
+		}
+		return 1.0
+	}
+}
+
+// moonStoneFamilies lists the species that evolve, or evolve from a species
+// that evolves, with a Moon Stone.
+var moonStoneFamilies = []string{
+	"nidoran-f", "nidorina", "nidoqueen",
+	"nidoran-m", "nidorino", "nidoking",
+	"cleffa", "clefairy", "clefable",
+	"igglybuff", "jigglypuff", "wigglytuff",
+	"skitty", "delcatty",
+	"munna", "musharna",
+}
+
+var pokeballs = map[string]pokeball{
+	"poke-ball":    {modifier: flat(1.0)},
+	"great-ball":   {modifier: flat(1.5)},
+	"ultra-ball":   {modifier: flat(2.0)},
+	"safari-ball":  {modifier: flat(1.5)},
+	"premier-ball": {modifier: flat(1.0)},
+	"luxury-ball":  {modifier: flat(1.0)},
+	"heal-ball": {
+		modifier: flat(1.0),
+		onCatch: func(config *Config, pokemon *Pokemon) {
+			pokemon.CurrentHP = pokemon.maxHP()
+			pokemon.Status = capture.StatusNone
+			fmt.Println(pokemon.displayName(config) + " was fully healed.")
+		},
+	},
+	"cherish-ball": {modifier: flat(1.0)},
+	"net-ball": {modifier: when(3.5, func(c catchContext) bool {
+		return c.hasType("water", "bug")
+	})},
+	"dive-ball": {modifier: when(3.5, func(c catchContext) bool {
+		return slices.Contains([]string{"surf", "old-rod", "good-rod", "super-rod"}, c.method)
+	})},
+	"repeat-ball": {modifier: when(3.5, func(c catchContext) bool {
+		_, ok := c.pokedex[c.species.Name]
+		return ok
+	})},
+	"quick-ball": {modifier: when(5.0, func(c catchContext) bool {
+		return c.turn == 1
+	})},
+	"timer-ball": {modifier: func(c catchContext) float64 {
+		return min(1.0+float64(max(c.turn-1, 0))*1229.0/4096.0, 4.0)
+	}},
+	"dusk-ball": {modifier: when(3.0, func(c catchContext) bool {
+		return c.night() || c.cave
+	})},
+	"nest-ball": {modifier: func(c catchContext) float64 {
+		if c.level <= 0 || c.level >= 30 {
+			return 1.0
+		}
+		return float64(41-c.level) / 10.0
+	}},
+	"level-ball": {modifier: func(c catchContext) float64 {
+		switch {
+		case c.level <= 0 || c.leadLevel <= c.level:
+			return 1.0
+		case c.leadLevel > 4*c.level:
+			return 8.0
+		case c.leadLevel > 2*c.level:
+			return 4.0
+		default:
+			return 2.0
+		}
+	}},
+	"heavy-ball": {
+		modifier: flat(1.0),
+		rateBonus: func(c catchContext) int {
+			// Weight is measured in hectograms.
+			switch {
+			case c.pokemon.Weight < 1000:
+				return -20
+			case c.pokemon.Weight < 2000:
+				return 0
+			case c.pokemon.Weight < 3000:
+				return 20
+			default:
+				return 30
+			}
+		},
+	},
+	"fast-ball": {modifier: when(4.0, func(c catchContext) bool {
+		return baseStat(c.pokemon, "speed") >= 100
+	})},
+	"moon-ball": {modifier: when(4.0, func(c catchContext) bool {
+		return slices.Contains(moonStoneFamilies, c.species.Name)
+	})},
+	"love-ball": {modifier: when(8.0, func(c catchContext) bool {
+		return c.leadSpecies == c.species.Name &&
+			c.gender != "" && c.leadGender != "" && c.gender != c.leadGender
+	})},
+}
+
+// rate returns the species' catch rate, adjusted by any additive bonus the
+// ball gives for the throw.
+func (ball pokeball) rate(c catchContext) int {
+	rate := c.species.CaptureRate
+	if ball.rateBonus != nil {
+		rate = max(rate+ball.rateBonus(c), 1)
+	}
+	return rate
+}
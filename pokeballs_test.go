@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestBallModifiers(t *testing.T) {
+	magikarp := pokeapi.Pokemon{
+		Weight: 100,
+		Types:  []pokeapi.PokemonType{{Slot: 1, Type: pokeapi.Resource{Name: "water"}}},
+		Stats:  []pokeapi.PokemonStat{{Stat: pokeapi.Resource{Name: "speed"}, BaseStat: 80}},
+	}
+	noon := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		ball     string
+		context  catchContext
+		expected float64
+	}{
+		{ball: "net-ball", context: catchContext{pokemon: magikarp}, expected: 3.5},
+		{ball: "net-ball", context: catchContext{}, expected: 1.0},
+		{ball: "quick-ball", context: catchContext{turn: 1}, expected: 5.0},
+		{ball: "quick-ball", context: catchContext{turn: 2}, expected: 1.0},
+		{ball: "timer-ball", context: catchContext{turn: 1}, expected: 1.0},
+		{ball: "timer-ball", context: catchContext{turn: 20}, expected: 4.0},
+		{ball: "dusk-ball", context: catchContext{now: noon}, expected: 1.0},
+		{ball: "dusk-ball", context: catchContext{now: midnight}, expected: 3.0},
+		{ball: "dusk-ball", context: catchContext{now: noon, cave: true}, expected: 3.0},
+		{ball: "nest-ball", context: catchContext{level: 11}, expected: 3.0},
+		{ball: "level-ball", context: catchContext{level: 10, leadLevel: 45}, expected: 8.0},
+		{ball: "level-ball", context: catchContext{level: 10, leadLevel: 25}, expected: 4.0},
+		{ball: "level-ball", context: catchContext{level: 10, leadLevel: 10}, expected: 1.0},
+		{ball: "fast-ball", context: catchContext{pokemon: magikarp}, expected: 1.0},
+		{ball: "dive-ball", context: catchContext{method: "super-rod"}, expected: 3.5},
+		{
+			ball: "repeat-ball",
+			context: catchContext{
+				species: pokeapi.PokemonSpecies{Name: "magikarp"},
+				pokedex: map[string]PokedexEntry{"magikarp": {Caught: 1}},
+			},
+			expected: 3.5,
+		},
+	}
+	for _, c := range cases {
+		if actual := pokeballs[c.ball].modifier(c.context); actual != c.expected {
+			t.Errorf("%s: [Expected, Received]: [%v, %v]", c.ball, c.expected, actual)
+		}
+	}
+}
+
+func TestHeavyBallRate(t *testing.T) {
+	context := catchContext{
+		pokemon: pokeapi.Pokemon{Weight: 2100},
+		species: pokeapi.PokemonSpecies{CaptureRate: 45},
+	}
+	if actual := pokeballs["heavy-ball"].rate(context); actual != 65 {
+		t.Errorf("[Expected, Received]: [%v, %v]", 65, actual)
+	}
+	context.pokemon.Weight = 60
+	context.species.CaptureRate = 3
+	if actual := pokeballs["heavy-ball"].rate(context); actual != 1 {
+		t.Errorf("[Expected, Received]: [%v, %v]", 1, actual)
+	}
+}
@@ -1,44 +1,152 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/jthughes/pokedexcli/internal/lineedit"
 	"github.com/jthughes/pokedexcli/internal/pokecache"
 )
 
 var commands map[string]cliCommand
 
-func repl() {
-	commands = registerCommands()
+// newConfig returns the game state for a profile, loading its save. The
+// empty profile is the default one.
+func newConfig(seed uint64, profile string) (*Config, error) {
 	interval, err := time.ParseDuration("5s")
 	if err != nil {
-		fmt.Println("Unable to set duration:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("unable to set duration: %w", err)
 	}
 	config := Config{
-		Cache:   pokecache.NewCache(interval),
-		Pokedex: map[string]Pokemon{},
+		Cache:         pokecache.NewDiskCache(interval, defaultCacheDir()),
+		Pokedex:       map[string]PokedexEntry{},
+		Boxes:         newBoxes(),
+		Bag:           defaultBag(),
+		Settings:      defaultSettings(),
+		Seed:          seed,
+		RNG:           newRNG(seed),
+		SimulationRNG: newSimulationRNG(seed),
+		Clock:         realClock{},
+		SavePath:      defaultSavePath(profile),
+		IndexDir:      defaultIndexDir(),
+		HistoryPath:   defaultHistoryPath(profile),
+	}
+	if err := loadGame(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func repl(config *Config) {
+	// The history is loaded after the game, which holds its size setting.
+	if err := loadHistory(config); err != nil {
+		fmt.Println(err.Error())
+	}
+	editor := lineedit.New(os.Stdin, os.Stdout)
+	editor.Complete = func(head string) []string {
+		return completions(config, head)
 	}
-	scanner := bufio.NewScanner(os.Stdin)
 	for {
-		fmt.Print("Pokedex > ")
-		scanner.Scan()
-		input := scanner.Text()
-		words := cleanInput(input)
-		command, ok := commands[words[0]]
-		if !ok {
-			fmt.Println("Unknown command")
+		editor.SetHistory(config.History)
+		input, err := editor.ReadLine("Pokedex > ")
+		if errors.Is(err, lineedit.ErrInterrupted) {
 			continue
 		}
-		err := command.callback(&config, words)
+		if errors.Is(err, io.EOF) {
+			// The input has ended, such as when it was piped in.
+			fmt.Println()
+			commandExit(config, nil)
+			return
+		}
+		if err != nil {
+			fmt.Println("Unable to read input:", err)
+			if err := saveGame(config); err != nil {
+				fmt.Println(err.Error())
+			}
+			return
+		}
+		expanded, err := expandHistory(config, input)
 		if err != nil {
 			fmt.Println(err.Error())
+			continue
+		}
+		if expanded != input {
+			fmt.Println(expanded)
+			input = expanded
 		}
+		addHistory(config, input)
+		if err := saveHistory(config); err != nil {
+			fmt.Println(err.Error())
+		}
+		if err := execute(config, input); err != nil {
+			printError(config, err)
+		}
+		if err := saveGame(config); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+}
+
+// unknownCommandError is returned for input naming no command.
+type unknownCommandError struct {
+	name string
+}
+
+func (err *unknownCommandError) Error() string {
+	return "unknown command " + err.name
+}
+
+// usageError is returned when a command is given arguments it doesn't take.
+type usageError struct {
+	usage string
+}
+
+func (err *usageError) Error() string {
+	return "Expecting: " + err.usage
+}
+
+// usage returns an error showing how a command is used.
+func usage(usage string) error {
+	return &usageError{usage: usage}
+}
+
+// suggestionError is returned when a command is given a name it doesn't
+// know, such as one of the trainer's Pokemon. Printing it suggests the
+// closest of the names that were expected.
+type suggestionError struct {
+	message    string
+	name       string
+	candidates []string
+}
+
+func (err *suggestionError) Error() string {
+	return err.message
+}
+
+// suggest returns an error with message, suggesting the candidates closest to
+// name when it is printed.
+func suggest(message, name string, candidates []string) error {
+	return &suggestionError{message: message, name: name, candidates: candidates}
+}
+
+// execute runs the command on a line of input. Blank input does nothing.
+func execute(config *Config, input string) error {
+	words := cleanInput(input)
+	if len(words) == 0 {
+		return nil
 	}
+	command, ok := commands[words[0]]
+	if !ok {
+		return &unknownCommandError{name: words[0]}
+	}
+	config.Input = strings.Fields(input)
+	return command.callback(config, words)
 }
 
 func cleanInput(text string) []string {
@@ -46,6 +154,35 @@ func cleanInput(text string) []string {
 	return words
 }
 
+// parseFlags splits args into positional arguments and "--name" flags.
+// Flags listed in valued take the following word as their value; any other
+// flag is a switch and is recorded as "true".
+func parseFlags(args []string, valued ...string) ([]string, map[string]string, error) {
+	positional := []string{}
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		name, ok := strings.CutPrefix(args[i], "--")
+		if !ok || name == "" {
+			positional = append(positional, args[i])
+			continue
+		}
+		if name, value, ok := strings.Cut(name, "="); ok {
+			flags[name] = value
+			continue
+		}
+		if !slices.Contains(valued, name) {
+			flags[name] = "true"
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, nil, fmt.Errorf("flag --%s expects a value", name)
+		}
+		flags[name] = args[i+1]
+		i++
+	}
+	return positional, flags, nil
+}
+
 type cliCommand struct {
 	name        string
 	description string
@@ -56,7 +193,51 @@ type Config struct {
 	Next     *string
 	Previous *string
 	Cache    *pokecache.Cache
-	Pokedex  map[string]Pokemon
+	// Pokedex records the species caught, by name, while the Pokemon
+	// themselves are kept in the party and the PC boxes.
+	Pokedex map[string]PokedexEntry
+	Party   []Pokemon
+	Boxes   [][]Pokemon
+	Bag     Bag
+	// Location is the location area the trainer is in.
+	Location string
+	Settings Settings
+	// Wild is the wild Pokemon the trainer is facing, if any, and Battle the
+	// battle against it.
+	Wild      *wildPokemon
+	Battle    *battleState
+	TypeChart *typeChart
+	Seed      uint64
+	RNG       *rand.Rand
+	// SimulationRNG is drawn from by simulations, so that they don't change
+	// the outcome of later catches and encounters.
+	SimulationRNG *rand.Rand
+	Clock         Clock
+	// Input is the words of the command being run as they were entered,
+	// before being lowercased, for arguments such as file paths.
+	Input []string
+	// ScriptDepth counts the scripts being run, each sourced by the last.
+	ScriptDepth int
+	// Output is the format commands write their output in, one of
+	// outputFormats.
+	Output string
+	// SavePath is where the game is saved; empty disables saving. saved
+	// holds the game as last saved or loaded, so that unchanged games
+	// aren't written again.
+	SavePath string
+	saved    []byte
+	// NameIndex holds the names at each API endpoint, for suggesting names
+	// when one isn't found, and IndexDir is where they are cached on disk;
+	// empty disables the disk cache.
+	NameIndex map[string][]string
+	IndexDir  string
+	// LocalNameIndex maps, for each language, the species' names in it to
+	// their API names.
+	LocalNameIndex map[string]map[string]string
+	// History is the commands entered, oldest first, and HistoryPath the
+	// file they are kept in; empty disables keeping them.
+	History     []string
+	HistoryPath string
 }
 
 func registerCommands() (commands map[string]cliCommand) {
@@ -68,7 +249,7 @@ func registerCommands() (commands map[string]cliCommand) {
 	}
 	commands["map"] = cliCommand{
 		name:        "map",
-		description: "Displays the next 20 map locations",
+		description: "Displays the next 20 map locations, or a page of them: map [--page n]",
 		callback:    commandMapForward,
 	}
 	commands["mapb"] = cliCommand{
@@ -78,24 +259,179 @@ func registerCommands() (commands map[string]cliCommand) {
 	}
 	commands["explore"] = cliCommand{
 		name:        "explore",
-		description: "Displays the Pokemon found at the provided location",
+		description: "Displays the Pokemon found at the provided location, or the current one",
 		callback:    commandExplore,
 	}
 	commands["pokedex"] = cliCommand{
 		name:        "pokedex",
-		description: "List all Pokemon in the Pokedex",
+		description: "List all Pokemon in the Pokedex, or a regional Pokedex with --dex <name>",
 		callback:    commandPokedex,
 	}
 	commands["catch"] = cliCommand{
 		name:        "catch",
-		description: "Attempt to catch a Pokemon",
+		description: "Attempt to catch the wild Pokemon, optionally with a ball from your bag",
 		callback:    commandCatch,
 	}
+	commands["bag"] = cliCommand{
+		name:        "bag",
+		description: "List the items in your bag",
+		callback:    commandBag,
+	}
+	commands["travel"] = cliCommand{
+		name:        "travel",
+		description: "Travel to a location area",
+		callback:    commandTravel,
+	}
+	commands["here"] = cliCommand{
+		name:        "here",
+		description: "Describe the location area you are in",
+		callback:    commandHere,
+	}
+	commands["walk"] = cliCommand{
+		name:        "walk",
+		description: "Walk through the tall grass of the current area looking for wild Pokemon",
+		callback:    commandWalk,
+	}
+	commands["encounter"] = cliCommand{
+		name:        "encounter",
+		description: "Look for wild Pokemon in the current area: encounter [--method <method>]",
+		callback:    commandEncounter,
+	}
+	commands["run"] = cliCommand{
+		name:        "run",
+		description: "Run away from the wild Pokemon",
+		callback:    commandRun,
+	}
+	commands["type"] = cliCommand{
+		name:        "type",
+		description: "Show a type's strengths and weaknesses",
+		callback:    commandType,
+	}
+	commands["matchup"] = cliCommand{
+		name:        "matchup",
+		description: "Show a Pokemon's weaknesses, resistances and immunities",
+		callback:    commandMatchup,
+	}
+	commands["moves"] = cliCommand{
+		name:        "moves",
+		description: "List the moves a Pokemon learns: moves <pokemon> [--version-group <group>] [--method <method>]",
+		callback:    commandMoves,
+	}
+	commands["move"] = cliCommand{
+		name:        "move",
+		description: "Describe a move",
+		callback:    commandMove,
+	}
+	commands["evolution"] = cliCommand{
+		name:        "evolution",
+		description: "Show a Pokemon's evolution chain",
+		callback:    commandEvolution,
+	}
+	commands["evolve"] = cliCommand{
+		name:        "evolve",
+		description: "Evolve one of your Pokemon with an item or by trading: evolve <pokemon> [--item <item>] [--trade]",
+		callback:    commandEvolve,
+	}
+	commands["ability"] = cliCommand{
+		name:        "ability",
+		description: "Describe an ability and list the Pokemon that can have it",
+		callback:    commandAbility,
+	}
+	commands["battle"] = cliCommand{
+		name:        "battle",
+		description: "Battle the wild Pokemon in front of you",
+		callback:    commandBattle,
+	}
+	commands["fight"] = cliCommand{
+		name:        "fight",
+		description: "Use a move in battle, by name or number",
+		callback:    commandFight,
+	}
+	commands["switch"] = cliCommand{
+		name:        "switch",
+		description: "Send out a different Pokemon in battle",
+		callback:    commandSwitch,
+	}
+	commands["heal"] = cliCommand{
+		name:        "heal",
+		description: "Restore your Pokemon to full health at a Pokemon Center",
+		callback:    commandHeal,
+	}
+	commands["weaken"] = cliCommand{
+		name:        "weaken",
+		description: "Weaken the wild Pokemon without knocking it out",
+		callback:    commandWeaken,
+	}
+	commands["inflict"] = cliCommand{
+		name:        "inflict",
+		description: "Inflict a status condition on the wild Pokemon",
+		callback:    commandInflict,
+	}
 	commands["inspect"] = cliCommand{
 		name:        "inspect",
-		description: "Inspect a Pokemon in the Pokedex",
+		description: "Inspect one of your Pokemon, by name or party slot",
 		callback:    commandInspect,
 	}
+	commands["sprite"] = cliCommand{
+		name:        "sprite",
+		description: "Draw a Pokemon's sprite: sprite <pokemon> [--shiny] [--back] [--female]",
+		callback:    commandSprite,
+	}
+	commands["party"] = cliCommand{
+		name:        "party",
+		description: "List the Pokemon in your party",
+		callback:    commandParty,
+	}
+	commands["box"] = cliCommand{
+		name:        "box",
+		description: "List the Pokemon in a PC box, or how full each box is",
+		callback:    commandBox,
+	}
+	commands["deposit"] = cliCommand{
+		name:        "deposit",
+		description: "Deposit a party Pokemon in the PC",
+		callback:    commandDeposit,
+	}
+	commands["withdraw"] = cliCommand{
+		name:        "withdraw",
+		description: "Withdraw a Pokemon from the PC into your party",
+		callback:    commandWithdraw,
+	}
+	commands["swap"] = cliCommand{
+		name:        "swap",
+		description: "Swap the places of two party Pokemon",
+		callback:    commandSwap,
+	}
+	commands["simulate"] = cliCommand{
+		name:        "simulate",
+		description: "Simulate many catch attempts: simulate catch <pokemon> [ball] [--trials n]",
+		callback:    commandSimulate,
+	}
+	commands["seed"] = cliCommand{
+		name:        "seed",
+		description: "Show or set the random number generator seed",
+		callback:    commandSeed,
+	}
+	commands["history"] = cliCommand{
+		name:        "history",
+		description: "List the commands entered, or those containing some text; rerun one with !n, or the last with !!",
+		callback:    commandHistory,
+	}
+	commands["settings"] = cliCommand{
+		name:        "settings",
+		description: "List the current settings",
+		callback:    commandSettings,
+	}
+	commands["set"] = cliCommand{
+		name:        "set",
+		description: "Change a setting",
+		callback:    commandSet,
+	}
+	commands["source"] = cliCommand{
+		name:        "source",
+		description: "Run the commands in a script",
+		callback:    commandSource,
+	}
 	commands["exit"] = cliCommand{
 		name:        "exit",
 		description: "Exit the Pokedex",
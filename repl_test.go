@@ -1,6 +1,10 @@
 package main
 
-import "testing"
+import (
+	"maps"
+	"slices"
+	"testing"
+)
 
 func TestCleanInput(t *testing.T) {
 	cases := []struct {
@@ -43,3 +47,48 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestParseFlags(t *testing.T) {
+	cases := []struct {
+		input      []string
+		valued     []string
+		positional []string
+		flags      map[string]string
+	}{
+		{
+			input:      []string{"--dex", "kanto"},
+			valued:     []string{"dex"},
+			positional: []string{},
+			flags:      map[string]string{"dex": "kanto"},
+		},
+		{
+			input:      []string{"pikachu", "--all", "--version=red"},
+			positional: []string{"pikachu"},
+			flags:      map[string]string{"all": "true", "version": "red"},
+		},
+		{
+			input:      []string{"--dex", "original-johto", "extra"},
+			valued:     []string{"dex"},
+			positional: []string{"extra"},
+			flags:      map[string]string{"dex": "original-johto"},
+		},
+	}
+
+	for _, c := range cases {
+		positional, flags, err := parseFlags(c.input, c.valued...)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if !slices.Equal(positional, c.positional) {
+			t.Errorf("[Expected, Received]: [%v, %v]", c.positional, positional)
+		}
+		if !maps.Equal(flags, c.flags) {
+			t.Errorf("[Expected, Received]: [%v, %v]", c.flags, flags)
+		}
+	}
+
+	if _, _, err := parseFlags([]string{"--dex"}, "dex"); err == nil {
+		t.Errorf("expected an error for a missing flag value")
+	}
+}
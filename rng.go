@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+)
+
+// Clock is the source of time for the game, so that catches can be replayed
+// without waiting on animations.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func newRNG(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// newSimulationRNG returns the generator for simulations, seeded from the
+// game's seed but drawing a different sequence.
+func newSimulationRNG(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, ^seed))
+}
+
+func commandSeed(config *Config, args []string) error {
+	if len(args) == 1 {
+		fmt.Println("Seed:", config.Seed)
+		return nil
+	}
+	if len(args) != 2 {
+		return usage("seed [n]")
+	}
+	seed, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid seed: %w", err)
+	}
+	config.Seed = seed
+	config.RNG = newRNG(seed)
+	config.SimulationRNG = newSimulationRNG(seed)
+	fmt.Println("Seed set to", seed)
+	return nil
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jthughes/pokedexcli/internal/capture"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// saveData is the game state persisted between sessions.
+type saveData struct {
+	Location string
+	Pokedex  map[string]PokedexEntry
+	Party    []storedPokemon
+	Boxes    [][]storedPokemon
+	Bag      Bag
+	Settings Settings
+	// Wild is the wild Pokemon the trainer is facing, if any. A battle with
+	// it isn't saved, so it is left to start again after loading.
+	Wild *storedWild `json:",omitempty"`
+}
+
+// storedPokemon is one of the trainer's Pokemon as saved. Only what sets the
+// individual apart is kept; its Pokemon and species data are fetched again,
+// through the cache, when the game is loaded.
+type storedPokemon struct {
+	Name       string
+	Ball       string
+	Friendship int
+	Level      int
+	Experience int
+	IVs        Stats
+	EVs        Stats
+	Nature     Nature
+	Ability    pokeapi.PokemonAbility
+	Gender     string
+	Shiny      bool
+	KnownMoves []string
+	CurrentHP  int
+	Status     capture.Status
+}
+
+func storePokemon(pokemon Pokemon) storedPokemon {
+	return storedPokemon{
+		Name:       pokemon.Name,
+		Ball:       pokemon.Ball,
+		Friendship: pokemon.Friendship,
+		Level:      pokemon.Level,
+		Experience: pokemon.Experience,
+		IVs:        pokemon.IVs,
+		EVs:        pokemon.EVs,
+		Nature:     pokemon.Nature,
+		Ability:    pokemon.Ability,
+		Gender:     pokemon.Gender,
+		Shiny:      pokemon.Shiny,
+		KnownMoves: pokemon.KnownMoves,
+		CurrentHP:  pokemon.CurrentHP,
+		Status:     pokemon.Status,
+	}
+}
+
+// restorePokemon fetches the data of a saved Pokemon.
+func restorePokemon(config *Config, stored storedPokemon) (Pokemon, error) {
+	pokemon, err := pokeapi.GetPokemon(stored.Name, config.Cache)
+	if err != nil {
+		return Pokemon{}, fmt.Errorf("unable to restore %s: %w", stored.Name, err)
+	}
+	species, err := pokeapi.GetPokemonSpecies(pokemon.Species.Name, config.Cache)
+	if err != nil {
+		return Pokemon{}, fmt.Errorf("unable to restore %s: %w", stored.Name, err)
+	}
+	return Pokemon{
+		Pokemon:    pokemon,
+		Species:    species,
+		Ball:       stored.Ball,
+		Friendship: stored.Friendship,
+		Level:      stored.Level,
+		Experience: stored.Experience,
+		IVs:        stored.IVs,
+		EVs:        stored.EVs,
+		Nature:     stored.Nature,
+		Ability:    stored.Ability,
+		Gender:     stored.Gender,
+		Shiny:      stored.Shiny,
+		KnownMoves: stored.KnownMoves,
+		CurrentHP:  stored.CurrentHP,
+		Status:     stored.Status,
+	}, nil
+}
+
+func storeAll(pokemon []Pokemon) []storedPokemon {
+	stored := []storedPokemon{}
+	for _, p := range pokemon {
+		stored = append(stored, storePokemon(p))
+	}
+	return stored
+}
+
+func restoreAll(config *Config, stored []storedPokemon) ([]Pokemon, error) {
+	pokemon := []Pokemon{}
+	for _, s := range stored {
+		p, err := restorePokemon(config, s)
+		if err != nil {
+			return nil, err
+		}
+		pokemon = append(pokemon, p)
+	}
+	return pokemon, nil
+}
+
+// storedWild is the wild Pokemon being faced as saved, kept like a
+// storedPokemon.
+type storedWild struct {
+	Name       string
+	Level      int
+	IVs        Stats
+	Nature     Nature
+	Ability    pokeapi.PokemonAbility
+	Gender     string
+	Shiny      bool
+	KnownMoves []string
+	CurrentHP  int
+	Status     capture.Status
+	Turn       int
+	Method     string
+}
+
+func storeWild(wild *wildPokemon) *storedWild {
+	if wild == nil {
+		return nil
+	}
+	return &storedWild{
+		Name:       wild.Pokemon.Name,
+		Level:      wild.Level,
+		IVs:        wild.IVs,
+		Nature:     wild.Nature,
+		Ability:    wild.Ability,
+		Gender:     wild.Gender,
+		Shiny:      wild.Shiny,
+		KnownMoves: wild.KnownMoves,
+		CurrentHP:  wild.CurrentHP,
+		Status:     wild.Status,
+		Turn:       wild.Turn,
+		Method:     wild.Method,
+	}
+}
+
+// restoreWild fetches the data of a saved wild Pokemon.
+func restoreWild(config *Config, stored storedWild) (*wildPokemon, error) {
+	pokemon, err := pokeapi.GetPokemon(stored.Name, config.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore the wild %s: %w", stored.Name, err)
+	}
+	species, err := pokeapi.GetPokemonSpecies(pokemon.Species.Name, config.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore the wild %s: %w", stored.Name, err)
+	}
+	return &wildPokemon{
+		Pokemon:    pokemon,
+		Species:    species,
+		Level:      stored.Level,
+		IVs:        stored.IVs,
+		Nature:     stored.Nature,
+		Ability:    stored.Ability,
+		Gender:     stored.Gender,
+		Shiny:      stored.Shiny,
+		KnownMoves: stored.KnownMoves,
+		CurrentHP:  stored.CurrentHP,
+		Status:     stored.Status,
+		Turn:       stored.Turn,
+		Method:     stored.Method,
+	}, nil
+}
+
+// dataDir returns the directory the game keeps its files in, following the
+// XDG base directory specification.
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "pokedexcli"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "pokedexcli"), nil
+}
+
+// profileDir returns the directory a profile's save and history are kept in.
+// The default profile, named "", keeps them in the data directory itself.
+func profileDir(profile string) (string, error) {
+	dir, err := dataDir()
+	if err != nil || profile == "" {
+		return dir, err
+	}
+	return filepath.Join(dir, "profiles", profile), nil
+}
+
+// defaultCacheDir returns the directory API responses are cached in, or ""
+// when there is none and responses are only cached in memory.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli")
+}
+
+func defaultSavePath(profile string) string {
+	dir, err := profileDir(profile)
+	if err != nil {
+		fmt.Println("Unable to locate a save file, progress will not be saved:", err)
+		return ""
+	}
+	return filepath.Join(dir, "save.json")
+}
+
+// loadGame restores the game state from config.SavePath, leaving config
+// untouched if there is no save file yet.
+func loadGame(config *Config) error {
+	if config.SavePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(config.SavePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read save file: %w", err)
+	}
+	// Settings missing from older saves keep their defaults.
+	save := saveData{Settings: config.Settings}
+	if err := json.Unmarshal(data, &save); err != nil {
+		return fmt.Errorf("unable to unmarshall save file: %w", err)
+	}
+	if save.Party != nil {
+		if config.Party, err = restoreAll(config, save.Party); err != nil {
+			return err
+		}
+	}
+	if save.Boxes != nil {
+		config.Boxes = [][]Pokemon{}
+		for _, box := range save.Boxes {
+			restored, err := restoreAll(config, box)
+			if err != nil {
+				return err
+			}
+			config.Boxes = append(config.Boxes, restored)
+		}
+	}
+	if save.Wild != nil {
+		if config.Wild, err = restoreWild(config, *save.Wild); err != nil {
+			return err
+		}
+	}
+	config.Location = save.Location
+	if save.Pokedex != nil {
+		config.Pokedex = save.Pokedex
+	}
+	if save.Bag != nil {
+		config.Bag = save.Bag
+	}
+	config.Settings = save.Settings
+	config.saved, err = marshalSave(config)
+	return err
+}
+
+func marshalSave(config *Config) ([]byte, error) {
+	save := saveData{
+		Location: config.Location,
+		Pokedex:  config.Pokedex,
+		Party:    storeAll(config.Party),
+		Boxes:    [][]storedPokemon{},
+		Bag:      config.Bag,
+		Settings: config.Settings,
+		Wild:     storeWild(config.Wild),
+	}
+	for _, box := range config.Boxes {
+		save.Boxes = append(save.Boxes, storeAll(box))
+	}
+	data, err := json.Marshal(save)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshall save file: %w", err)
+	}
+	return data, nil
+}
+
+// saveGame writes the game state to config.SavePath, unless it hasn't
+// changed since it was last saved or loaded.
+func saveGame(config *Config) error {
+	if config.SavePath == "" {
+		return nil
+	}
+	data, err := marshalSave(config)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, config.saved) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(config.SavePath), 0o755); err != nil {
+		return fmt.Errorf("unable to create save directory: %w", err)
+	}
+	// Write to a temporary file first so a crash can't leave a partial save.
+	temp := config.SavePath + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return fmt.Errorf("unable to write save file: %w", err)
+	}
+	if err := os.Rename(temp, config.SavePath); err != nil {
+		return fmt.Errorf("unable to write save file: %w", err)
+	}
+	config.saved = data
+	return nil
+}
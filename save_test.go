@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+	"github.com/jthughes/pokedexcli/internal/pokecache"
+)
+
+// cachePokemon caches the API data of a Pokemon, so that it can be restored
+// from a save without the network.
+func cachePokemon(cache *pokecache.Cache, name string, captureRate int) {
+	cache.Add("https://pokeapi.co/api/v2/pokemon/"+name,
+		[]byte(`{"name": "`+name+`", "species": {"name": "`+name+`"}}`))
+	cache.Add("https://pokeapi.co/api/v2/pokemon-species/"+name,
+		[]byte(`{"name": "`+name+`", "capture_rate": `+strconv.Itoa(captureRate)+`}`))
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	cache := pokecache.NewCache(time.Minute)
+	cachePokemon(cache, "pikachu", 190)
+	cachePokemon(cache, "raichu", 75)
+	config := testConfig(1)
+	config.SavePath = path
+	config.Location = "viridian-forest-area"
+	config.Bag = Bag{"great-ball": 2}
+	config.Settings.FreeCatch = true
+	config.Pokedex["pikachu"] = PokedexEntry{Caught: 2}
+	config.Party = []Pokemon{{
+		Pokemon: pokeapi.Pokemon{Name: "pikachu", Species: pokeapi.Resource{Name: "pikachu"}},
+		Species: pokeapi.PokemonSpecies{Name: "pikachu", CaptureRate: 190},
+		Level:   7,
+	}}
+	config.Boxes[3] = []Pokemon{{Pokemon: pokeapi.Pokemon{Name: "raichu"}, Level: 30}}
+	config.Wild = testWild()
+	config.Wild.CurrentHP = 5
+	config.Wild.Turn = 2
+	if err := saveGame(config); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "capture_rate") {
+		t.Errorf("expected species data to be left out of the save: %s", data)
+	}
+
+	loaded := testConfig(1)
+	loaded.SavePath = path
+	loaded.Cache = cache
+	if err := loadGame(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Location != config.Location {
+		t.Errorf("[Expected, Received]: ['%s', '%s']", config.Location, loaded.Location)
+	}
+	if len(loaded.Bag) != 1 || loaded.Bag["great-ball"] != 2 {
+		t.Errorf("[Expected, Received]: [%v, %v]", config.Bag, loaded.Bag)
+	}
+	if !loaded.Settings.FreeCatch {
+		t.Errorf("expected settings to be restored")
+	}
+	if loaded.Pokedex["pikachu"].Caught != 2 {
+		t.Errorf("[Expected, Received]: [%v, %v]", config.Pokedex, loaded.Pokedex)
+	}
+	if len(loaded.Party) != 1 {
+		t.Fatalf("[Expected, Received]: [1, %d]", len(loaded.Party))
+	}
+	if len(loaded.Boxes[3]) != 1 || loaded.Boxes[3][0].Name != "raichu" {
+		t.Errorf("expected raichu to be restored to box 4: %+v", loaded.Boxes[3])
+	}
+	pikachu := loaded.Party[0]
+	if pikachu.Level != 7 || pikachu.Species.CaptureRate != 190 || pikachu.Pokemon.Species.Name != "pikachu" {
+		t.Errorf("pikachu was not restored: %+v", pikachu)
+	}
+	wild := loaded.Wild
+	if wild == nil || wild.Pokemon.Name != "pikachu" || wild.Level != 10 || wild.CurrentHP != 5 || wild.Turn != 2 || wild.Species.CaptureRate != 190 {
+		t.Errorf("the wild pikachu was not restored: %+v", wild)
+	}
+}
+
+func TestLoadMissingSave(t *testing.T) {
+	config := testConfig(1)
+	config.SavePath = filepath.Join(t.TempDir(), "save.json")
+	if err := loadGame(config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Bag["poke-ball"] != defaultBag()["poke-ball"] {
+		t.Errorf("expected a new game to keep the default bag")
+	}
+}
+
+func TestSaveUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	config := testConfig(1)
+	config.SavePath = path
+	if err := saveGame(config); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveGame(config); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected an unchanged game not to be saved again")
+	}
+	config.Location = "viridian-forest-area"
+	if err := saveGame(config); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected a changed game to be saved: %v", err)
+	}
+}
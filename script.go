@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// maxScriptDepth is how deeply scripts may source other scripts, to stop a
+// script sourcing itself forever.
+const maxScriptDepth = 16
+
+// assignment matches a line setting a script variable, such as
+// "ball=ultra-ball".
+var assignment = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+
+// reference matches a use of a script variable, as $name or ${name}.
+var reference = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// script is the state of a script being run.
+type script struct {
+	path string
+	vars map[string]string
+	// stopOnError, set with "set -e", stops the script at the first failing
+	// command, and echo, set with "set -x", prints each command before it
+	// runs.
+	stopOnError bool
+	echo        bool
+	failed      int
+}
+
+// runScript runs the commands in the file at path, one per line. Besides
+// commands, a script can contain:
+//
+//	# comments
+//	name=value     setting a variable, used as $name or ${name}
+//	echo text      printing text
+//	set -e / +e    stopping at, or continuing past, a failing command
+//	set -x / +x    printing, or not, each command before it runs
+//
+// It returns an error if a command failed.
+func runScript(config *Config, path string) error {
+	if config.ScriptDepth >= maxScriptDepth {
+		return fmt.Errorf("scripts are sourced more than %d deep", maxScriptDepth)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open script: %w", err)
+	}
+	defer file.Close()
+	config.ScriptDepth++
+	defer func() { config.ScriptDepth-- }()
+
+	s := &script{path: path, vars: map[string]string{}}
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		if err := s.runLine(config, scanner.Text()); err != nil {
+			// In JSON the error stands alone, and the script's own error
+			// gives the line it stopped at.
+			if config.Output != "json" {
+				fmt.Printf("%s:%d: ", path, n)
+			}
+			printError(config, err)
+			s.failed++
+			if s.stopOnError {
+				return fmt.Errorf("%s stopped at line %d", path, n)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read script: %w", err)
+	}
+	switch {
+	case s.failed == 1:
+		return fmt.Errorf("a command in %s failed", path)
+	case s.failed > 1:
+		return fmt.Errorf("%d commands in %s failed", s.failed, path)
+	}
+	return nil
+}
+
+func (s *script) runLine(config *Config, line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+	line, err := s.expand(line)
+	if err != nil {
+		return err
+	}
+	if match := assignment.FindStringSubmatch(line); match != nil {
+		s.vars[match[1]] = strings.TrimSpace(match[2])
+		return nil
+	}
+	if s.echo {
+		fmt.Println("+ " + line)
+	}
+	name, rest, _ := strings.Cut(line, " ")
+	switch {
+	case name == "echo":
+		fmt.Println(strings.TrimSpace(rest))
+		return nil
+	case name == "set" && s.setOption(strings.TrimSpace(rest)):
+		return nil
+	}
+	return execute(config, line)
+}
+
+// expand replaces the variables used in line with their values.
+func (s *script) expand(line string) (string, error) {
+	var err error
+	expanded := reference.ReplaceAllStringFunc(line, func(ref string) string {
+		match := reference.FindStringSubmatch(ref)
+		name := match[1] + match[2]
+		value, ok := s.vars[name]
+		if !ok && err == nil {
+			err = errors.New("undefined variable " + name)
+		}
+		return value
+	})
+	return expanded, err
+}
+
+// setOption sets a script option, reporting whether option was one.
+func (s *script) setOption(option string) bool {
+	switch option {
+	case "-e":
+		s.stopOnError = true
+	case "+e":
+		s.stopOnError = false
+	case "-x":
+		s.echo = true
+	case "+x":
+		s.echo = false
+	default:
+		return false
+	}
+	return true
+}
+
+func commandSource(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("source <file>")
+	}
+	// File paths are case sensitive, so the path is taken as it was entered.
+	path := args[1]
+	if len(config.Input) == len(args) {
+		path = config.Input[1]
+	}
+	return runScript(config, path)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// addTestCommand registers a command for the length of a test.
+func addTestCommand(t *testing.T, name string, callback func(*Config, []string) error) {
+	t.Helper()
+	commands[name] = cliCommand{name: name, callback: callback}
+	t.Cleanup(func() {
+		delete(commands, name)
+	})
+}
+
+func TestRunScript(t *testing.T) {
+	commands = registerCommands()
+	ran := []string{}
+	addTestCommand(t, "record", func(config *Config, args []string) error {
+		ran = append(ran, strings.Join(args[1:], " "))
+		return nil
+	})
+	addTestCommand(t, "fail", func(*Config, []string) error {
+		return errors.New("failed")
+	})
+	cases := []struct {
+		name     string
+		script   string
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "comments and blank lines",
+			script:   "# setup\n\nrecord a\n  # indented\nrecord b\n",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "variables",
+			script:   "ball=ultra-ball\nname=Pikachu\nrecord $name ${ball}s\necho $name\n",
+			expected: []string{"pikachu ultra-balls"},
+		},
+		{
+			name:     "undefined variable",
+			script:   "record $missing\nrecord b\n",
+			expected: []string{"b"},
+			err:      true,
+		},
+		{
+			name:     "continue past errors",
+			script:   "record a\nfail\nrecord b\n",
+			expected: []string{"a", "b"},
+			err:      true,
+		},
+		{
+			name:     "stop on error",
+			script:   "set -e\nrecord a\nbogus\nrecord b\n",
+			expected: []string{"a"},
+			err:      true,
+		},
+		{
+			name:     "stop on a failed command",
+			script:   "set -e\nrecord a\nrun\nrecord b\n",
+			expected: []string{"a"},
+			err:      true,
+		},
+		{
+			name:     "stop on error turned off",
+			script:   "set -e\nset +e\nfail\nrecord a\n",
+			expected: []string{"a"},
+			err:      true,
+		},
+	}
+	for _, c := range cases {
+		ran = []string{}
+		path := filepath.Join(t.TempDir(), "script.pdx")
+		if err := os.WriteFile(path, []byte(c.script), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		err := runScript(testConfig(1), path)
+		if (err != nil) != c.err {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+		if !slices.Equal(ran, c.expected) {
+			t.Errorf("%s: [Expected, Received]: [%v, %v]", c.name, c.expected, ran)
+		}
+	}
+}
+
+func TestSourceTooDeep(t *testing.T) {
+	commands = registerCommands()
+	path := filepath.Join(t.TempDir(), "Loop.pdx")
+	if err := os.WriteFile(path, []byte("set -e\nsource "+path+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := testConfig(1)
+	if err := execute(config, "source "+path); err == nil {
+		t.Errorf("expected an error sourcing a script that sources itself")
+	}
+	if config.ScriptDepth != 0 {
+		t.Errorf("[Expected, Received]: [%d, %d]", 0, config.ScriptDepth)
+	}
+}
+
+func TestRunScriptCommand(t *testing.T) {
+	commands = registerCommands()
+	path := filepath.Join(t.TempDir(), "Demo Setup.pdx")
+	if err := os.WriteFile(path, []byte("set -e\nset free-catch on\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := testConfig(1)
+	if code := runCommand(config, []string{"run", path}); code != exitSuccess {
+		t.Errorf("[Expected, Received]: [%d, %d]", exitSuccess, code)
+	}
+	if !config.Settings.FreeCatch {
+		t.Errorf("expected the script to be run")
+	}
+	// Inside the Pokedex, run only runs from the wild Pokemon.
+	var wrongArgs *usageError
+	if err := execute(testConfig(1), "run "+path); !errors.As(err, &wrongArgs) {
+		t.Errorf("expected a usage error running a script with run, got %v", err)
+	}
+}
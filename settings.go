@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/jthughes/pokedexcli/internal/capture"
+	"github.com/jthughes/pokedexcli/internal/termimg"
+)
+
+type Settings struct {
+	CatchFormula string
+	// Generation selects the type chart and Pokemon types in use.
+	Generation int
+	// FreeCatch allows catching any Pokemon without encountering it first.
+	FreeCatch bool
+	// ShinyOdds is the chance, 1 in ShinyOdds, of a wild Pokemon being
+	// shiny, and ShinyCharm gives extra chances.
+	ShinyOdds  int
+	ShinyCharm bool
+	// SpriteMode is how sprites are drawn; empty detects what the terminal
+	// supports.
+	SpriteMode string
+	// Language is the language names are shown in, such as "ja"; empty
+	// shows the API's names.
+	Language string
+	// Version is the game version whose encounter tables are used. When
+	// empty, or when an area has no table for it, the first listed is used.
+	Version string
+	// HistorySize is how many commands are kept in the history.
+	HistorySize int
+}
+
+func defaultSettings() Settings {
+	return Settings{
+		CatchFormula: capture.Default,
+		Generation:   latestGeneration,
+		ShinyOdds:    defaultShinyOdds,
+		HistorySize:  defaultHistorySize,
+	}
+}
+
+type setting struct {
+	name        string
+	description string
+	get         func(*Config) string
+	set         func(*Config, string) error
+}
+
+func registerSettings() (settings map[string]setting) {
+	settings = map[string]setting{}
+	settings["catch-formula"] = setting{
+		name:        "catch-formula",
+		description: fmt.Sprintf("Catch formula to use, one of %v", capture.Names()),
+		get: func(config *Config) string {
+			return config.Settings.CatchFormula
+		},
+		set: func(config *Config, value string) error {
+			if _, err := capture.Get(value); err != nil {
+				return err
+			}
+			config.Settings.CatchFormula = value
+			return nil
+		},
+	}
+	settings["generation"] = setting{
+		name:        "generation",
+		description: fmt.Sprintf("Generation of the type chart and Pokemon types, 1 to %d", latestGeneration),
+		get: func(config *Config) string {
+			return strconv.Itoa(config.Settings.Generation)
+		},
+		set: func(config *Config, value string) error {
+			generation, err := parseGeneration(value)
+			if err != nil {
+				return err
+			}
+			config.Settings.Generation = generation
+			return nil
+		},
+	}
+	settings["free-catch"] = setting{
+		name:        "free-catch",
+		description: "Allow catching any Pokemon without encountering it first (on/off)",
+		get: func(config *Config) string {
+			return formatBool(config.Settings.FreeCatch)
+		},
+		set: func(config *Config, value string) error {
+			enabled, err := parseBool(value)
+			if err != nil {
+				return err
+			}
+			config.Settings.FreeCatch = enabled
+			return nil
+		},
+	}
+	settings["shiny-odds"] = setting{
+		name:        "shiny-odds",
+		description: "Chance of a wild Pokemon being shiny, as 1 in n",
+		get: func(config *Config) string {
+			return strconv.Itoa(config.Settings.ShinyOdds)
+		},
+		set: func(config *Config, value string) error {
+			odds, err := strconv.Atoi(value)
+			if err != nil || odds < 1 {
+				return fmt.Errorf("expecting a positive number, got %q", value)
+			}
+			config.Settings.ShinyOdds = odds
+			return nil
+		},
+	}
+	settings["history-size"] = setting{
+		name:        "history-size",
+		description: "Number of commands kept in the history",
+		get: func(config *Config) string {
+			return strconv.Itoa(config.Settings.HistorySize)
+		},
+		set: func(config *Config, value string) error {
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 0 {
+				return fmt.Errorf("expecting a number of commands, got %q", value)
+			}
+			config.Settings.HistorySize = size
+			trimHistory(config)
+			return saveHistory(config)
+		},
+	}
+	settings["shiny-charm"] = setting{
+		name:        "shiny-charm",
+		description: "Carry the Shiny Charm, for extra chances of meeting shiny Pokemon (on/off)",
+		get: func(config *Config) string {
+			return formatBool(config.Settings.ShinyCharm)
+		},
+		set: func(config *Config, value string) error {
+			enabled, err := parseBool(value)
+			if err != nil {
+				return err
+			}
+			config.Settings.ShinyCharm = enabled
+			return nil
+		},
+	}
+	settings["sprite-mode"] = setting{
+		name:        "sprite-mode",
+		description: "How to draw sprites: auto, kitty, iterm, sixel, truecolor, 256 or ascii",
+		get: func(config *Config) string {
+			if config.Settings.SpriteMode == "" {
+				return "auto"
+			}
+			return config.Settings.SpriteMode
+		},
+		set: func(config *Config, value string) error {
+			if value == "auto" {
+				config.Settings.SpriteMode = ""
+				return nil
+			}
+			if _, err := termimg.ParseMode(value); err != nil {
+				return err
+			}
+			config.Settings.SpriteMode = value
+			return nil
+		},
+	}
+	settings["language"] = setting{
+		name:        "language",
+		description: fmt.Sprintf("Language for names, one of %v, or \"none\" for the API's names", languages),
+		get: func(config *Config) string {
+			if config.Settings.Language == "" {
+				return "none"
+			}
+			return config.Settings.Language
+		},
+		set: func(config *Config, value string) error {
+			if value == "none" {
+				config.Settings.Language = ""
+				return nil
+			}
+			language, err := parseLanguage(value)
+			if err != nil {
+				return err
+			}
+			config.Settings.Language = language
+			return nil
+		},
+	}
+	settings["version"] = setting{
+		name:        "version",
+		description: "Game version used for encounter tables, e.g. red or heartgold (\"any\" for the first listed)",
+		get: func(config *Config) string {
+			if config.Settings.Version == "" {
+				return "any"
+			}
+			return config.Settings.Version
+		},
+		set: func(config *Config, value string) error {
+			if value == "any" {
+				value = ""
+			}
+			config.Settings.Version = value
+			return nil
+		},
+	}
+	return settings
+}
+
+func parseBool(value string) (bool, error) {
+	switch value {
+	case "on", "true", "yes":
+		return true, nil
+	case "off", "false", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("expecting on or off, got %q", value)
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
+
+func commandSettings(config *Config, args []string) error {
+	if len(args) != 1 {
+		return usage("settings")
+	}
+	settings := registerSettings()
+	for _, name := range slices.Sorted(maps.Keys(settings)) {
+		setting := settings[name]
+		fmt.Printf("%s = %s\n  %s\n", setting.name, setting.get(config), setting.description)
+	}
+	return nil
+}
+
+func commandSet(config *Config, args []string) error {
+	if len(args) != 3 {
+		return usage("set <setting> <value>")
+	}
+	setting, ok := registerSettings()[args[1]]
+	if !ok {
+		return suggest("Unknown setting: "+args[1], args[1], slices.Collect(maps.Keys(registerSettings())))
+	}
+	if err := setting.set(config, args[2]); err != nil {
+		return err
+	}
+	fmt.Println(setting.name + " set to " + setting.get(config))
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+const defaultTrials = 10_000
+
+func commandSimulate(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:], "trials")
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 || len(positional) > 3 || positional[0] != "catch" {
+		return usage("simulate catch <pokemon> [ball] [--trials n]")
+	}
+	trials := defaultTrials
+	if value, ok := flags["trials"]; ok {
+		trials, err = strconv.Atoi(value)
+		if err != nil || trials <= 0 {
+			return fmt.Errorf("invalid number of trials: %s", value)
+		}
+	}
+	pokemonName := positional[1]
+	ballName := "poke-ball"
+	if len(positional) == 3 {
+		ballName = positional[2]
+	}
+	if _, ok := pokeballs[ballName]; !ok {
+		return suggest("Unknown ball: "+ballName, ballName, slices.Collect(maps.Keys(pokeballs)))
+	}
+
+	// Simulate against the wild Pokemon being faced, as it stands, or a fresh
+	// one if the trainer isn't facing this Pokemon.
+	var wild wildPokemon
+	if config.Wild != nil && config.Wild.Pokemon.Name == pokemonName {
+		wild = *config.Wild
+	} else {
+		pokemon, err := pokeapi.GetPokemon(pokemonName, config.Cache)
+		if err != nil {
+			return err
+		}
+		pokemonSpecies, err := pokeapi.GetPokemonSpecies(pokemonName, config.Cache)
+		if err != nil {
+			return err
+		}
+		level := minWildLevel + config.SimulationRNG.IntN(maxWildLevel-minWildLevel+1)
+		spawned, err := spawnWild(config, config.SimulationRNG, pokemon, pokemonSpecies, level)
+		if err != nil {
+			return err
+		}
+		wild = *spawned
+	}
+	wild.Turn += 1
+
+	formula, input, err := catchInput(config, &wild, ballName)
+	if err != nil {
+		return err
+	}
+	caught, criticals := 0, 0
+	shakes := [4]int{}
+	for range trials {
+		result := formula.Throw(input, config.SimulationRNG)
+		if result.Critical {
+			criticals += 1
+		}
+		if result.Caught {
+			caught += 1
+			continue
+		}
+		shakes[result.Shakes] += 1
+	}
+
+	fmt.Printf("Simulated %d %ss at %s using the %s formula:\n",
+		trials, itemName(ballName), wild.describe(config), formula.Name())
+	fmt.Printf("  Caught: %d (%.2f%%, expected %.2f%%)\n",
+		caught, 100*float64(caught)/float64(trials), 100*formula.Probability(input))
+	fmt.Printf("  Critical captures: %d\n", criticals)
+	for n, count := range shakes {
+		fmt.Printf("  Broke free after %d shakes: %d (%.2f%%)\n", n, count, 100*float64(count)/float64(trials))
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestSimulateKeepsRNG(t *testing.T) {
+	config := testConfig(7)
+	config.Wild = testWild()
+	if err := commandSimulate(config, []string{"simulate", "catch", "pikachu", "--trials", "100"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := testConfig(7).RNG.Uint64()
+	if actual := config.RNG.Uint64(); actual != expected {
+		t.Errorf("[Expected, Received]: [%d, %d]", expected, actual)
+	}
+	// The simulation generator carries on, so the next simulation differs.
+	if config.SimulationRNG.Uint64() == testConfig(7).SimulationRNG.Uint64() {
+		t.Errorf("expected the simulation generator to have been advanced")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+	"github.com/jthughes/pokedexcli/internal/termimg"
+)
+
+// maxSpriteWidth is the widest, in columns, a sprite drawn with characters
+// may be.
+const maxSpriteWidth = 64
+
+// spriteURL picks the sprite to draw, falling back to the default sprite
+// when the species has no separate female or shiny one.
+func spriteURL(sprites pokeapi.PokemonSprites, shiny bool, back bool, female bool) string {
+	candidates := []string{}
+	switch {
+	case back && shiny:
+		if female {
+			candidates = append(candidates, sprites.BackShinyFemale)
+		}
+		candidates = append(candidates, sprites.BackShiny, sprites.BackDefault)
+	case back:
+		if female {
+			candidates = append(candidates, sprites.BackFemale)
+		}
+		candidates = append(candidates, sprites.BackDefault)
+	case shiny:
+		if female {
+			candidates = append(candidates, sprites.FrontShinyFemale)
+		}
+		candidates = append(candidates, sprites.FrontShiny, sprites.FrontDefault)
+	default:
+		if female {
+			candidates = append(candidates, sprites.FrontFemale)
+		}
+	}
+	candidates = append(candidates, sprites.FrontDefault)
+	for _, url := range candidates {
+		if url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// spriteMode returns the configured way of drawing sprites, detecting what
+// the terminal supports by default.
+func spriteMode(config *Config) (termimg.Mode, error) {
+	if config.Settings.SpriteMode == "" {
+		return termimg.Detect(os.Getenv), nil
+	}
+	return termimg.ParseMode(config.Settings.SpriteMode)
+}
+
+func printSprite(config *Config, pokemon pokeapi.Pokemon, shiny bool, back bool, female bool) error {
+	url := spriteURL(pokemon.Sprites, shiny, back, female)
+	if url == "" {
+		return errors.New("There is no sprite for " + pokemon.Name + ".")
+	}
+	data, err := pokeapi.GetSprite(url, config.Cache)
+	if err != nil {
+		return err
+	}
+	mode, err := spriteMode(config)
+	if err != nil {
+		return err
+	}
+	image, err := termimg.Render(data, mode, maxSpriteWidth)
+	if err != nil {
+		return err
+	}
+	fmt.Print(image)
+	return nil
+}
+
+func commandSprite(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usage("sprite <pokemon> [--shiny] [--back] [--female]")
+	}
+	pokemon, err := pokeapi.GetPokemon(positional[0], config.Cache)
+	if err != nil {
+		return err
+	}
+	return printSprite(config, pokemon, flags["shiny"] == "true", flags["back"] == "true", flags["female"] == "true")
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// statNames lists the stats in the order the games display them.
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+const (
+	maxIV      = 31
+	maxEV      = 252
+	maxTotalEV = 510
+)
+
+// Stats maps stat names to values, such as a Pokemon's IVs or EVs.
+type Stats map[string]int
+
+func (stats Stats) total() int {
+	total := 0
+	for _, value := range stats {
+		total += value
+	}
+	return total
+}
+
+func rollIVs(rng *rand.Rand) Stats {
+	ivs := Stats{}
+	for _, name := range statNames {
+		ivs[name] = rng.IntN(maxIV + 1)
+	}
+	return ivs
+}
+
+// gainEffort adds the effort values in yield to evs, up to the per-stat and
+// total limits.
+func gainEffort(evs Stats, yield []pokeapi.PokemonStat) {
+	for _, stat := range yield {
+		gain := min(stat.Effort, maxEV-evs[stat.Stat.Name], maxTotalEV-evs.total())
+		if gain > 0 {
+			evs[stat.Stat.Name] += gain
+		}
+	}
+}
+
+// Nature raises one stat by 10% and lowers another by 10%. Neutral natures
+// raise and lower the same stat, or none at all.
+type Nature struct {
+	Name      string
+	Increased string
+	Decreased string
+}
+
+func rollNature(config *Config, rng *rand.Rand) (Nature, error) {
+	id := 1 + rng.IntN(pokeapi.NatureCount)
+	nature, err := pokeapi.GetNature(strconv.Itoa(id), config.Cache)
+	if err != nil {
+		return Nature{}, err
+	}
+	return Nature{
+		Name:      nature.Name,
+		Increased: nature.IncreasedStat.Name,
+		Decreased: nature.DecreasedStat.Name,
+	}, nil
+}
+
+func (nature Nature) multiplier(stat string) float64 {
+	switch {
+	case nature.Increased == nature.Decreased:
+		return 1.0
+	case stat == nature.Increased:
+		return 1.1
+	case stat == nature.Decreased:
+		return 0.9
+	default:
+		return 1.0
+	}
+}
+
+func baseStat(pokemon pokeapi.Pokemon, name string) int {
+	for _, stat := range pokemon.Stats {
+		if stat.Stat.Name == name {
+			return stat.BaseStat
+		}
+	}
+	return 0
+}
+
+// calcHP returns the maximum HP at level using the main series formula.
+func calcHP(base, iv, ev, level int) int {
+	// Shedinja always has exactly 1 HP.
+	if base == 1 {
+		return 1
+	}
+	return (2*base+iv+ev/4)*level/100 + level + 10
+}
+
+// calcStat returns a stat other than HP at level using the main series
+// formula.
+func calcStat(base, iv, ev, level int, multiplier float64) int {
+	return int(float64((2*base+iv+ev/4)*level/100+5) * multiplier)
+}
+
+// computeStat returns the named stat of an individual Pokemon.
+func computeStat(pokemon pokeapi.Pokemon, name string, ivs, evs Stats, level int, nature Nature) int {
+	if name == "hp" {
+		return calcHP(baseStat(pokemon, name), ivs[name], evs[name], level)
+	}
+	return calcStat(baseStat(pokemon, name), ivs[name], evs[name], level, nature.multiplier(name))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestComputeStat(t *testing.T) {
+	// The level 78 Adamant Garchomp worked example from the games' stat formula.
+	garchomp := pokeapi.Pokemon{Name: "garchomp"}
+	for name, base := range map[string]int{
+		"hp": 108, "attack": 130, "defense": 95, "special-attack": 80, "special-defense": 85, "speed": 102,
+	} {
+		garchomp.Stats = append(garchomp.Stats, pokeapi.PokemonStat{Stat: pokeapi.Resource{Name: name}, BaseStat: base})
+	}
+	ivs := Stats{"hp": 24, "attack": 12, "defense": 30, "special-attack": 16, "special-defense": 23, "speed": 5}
+	evs := Stats{"hp": 74, "attack": 190, "defense": 91, "special-attack": 48, "special-defense": 84, "speed": 23}
+	adamant := Nature{Name: "adamant", Increased: "attack", Decreased: "special-attack"}
+	expected := Stats{"hp": 289, "attack": 278, "defense": 193, "special-attack": 135, "special-defense": 171, "speed": 171}
+	for _, name := range statNames {
+		if actual := computeStat(garchomp, name, ivs, evs, 78, adamant); actual != expected[name] {
+			t.Errorf("%s: [Expected, Received]: [%d, %d]", name, expected[name], actual)
+		}
+	}
+}
+
+func TestGainEffort(t *testing.T) {
+	evs := Stats{"attack": 251, "speed": 100}
+	yield := []pokeapi.PokemonStat{
+		{Stat: pokeapi.Resource{Name: "attack"}, Effort: 2},
+		{Stat: pokeapi.Resource{Name: "speed"}, Effort: 1},
+	}
+	gainEffort(evs, yield)
+	if evs["attack"] != maxEV || evs["speed"] != 101 {
+		t.Errorf("expected capped attack and 101 speed, got %v", evs)
+	}
+
+	evs = Stats{"hp": 252, "attack": 252, "defense": 5}
+	gainEffort(evs, []pokeapi.PokemonStat{{Stat: pokeapi.Resource{Name: "speed"}, Effort: 3}})
+	if evs.total() != maxTotalEV {
+		t.Errorf("expected total EVs capped at %d, got %d", maxTotalEV, evs.total())
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jthughes/pokedexcli/internal/capture"
+)
+
+const (
+	// partySize is the number of Pokemon a trainer can carry.
+	partySize = 6
+	// boxCount and boxSize are the number of PC boxes and how many Pokemon
+	// each holds.
+	boxCount = 18
+	boxSize  = 30
+)
+
+// PokedexEntry records a species the trainer has caught.
+type PokedexEntry struct {
+	Caught int
+	// Shiny counts the shiny Pokemon among those caught.
+	Shiny int
+}
+
+// newBoxes returns a set of empty PC boxes.
+func newBoxes() [][]Pokemon {
+	boxes := make([][]Pokemon, boxCount)
+	for i := range boxes {
+		boxes[i] = []Pokemon{}
+	}
+	return boxes
+}
+
+// storageFull reports whether there is no room left for another Pokemon in
+// the party or the PC.
+func storageFull(config *Config) bool {
+	if len(config.Party) < partySize {
+		return false
+	}
+	for _, box := range config.Boxes {
+		if len(box) < boxSize {
+			return false
+		}
+	}
+	return true
+}
+
+// store adds a newly caught Pokemon to the party, or to the first PC box with
+// room once the party is full, as in the games. It returns the number of the
+// box the Pokemon was sent to, or 0 if it joined the party.
+func store(config *Config, pokemon Pokemon) (int, error) {
+	if len(config.Party) < partySize {
+		config.Party = append(config.Party, pokemon)
+		return 0, nil
+	}
+	for i, box := range config.Boxes {
+		if len(box) < boxSize {
+			config.Boxes[i] = append(box, pokemon)
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("there is no room left for %s in the PC", pokemon.Name)
+}
+
+// partyIndex returns the index of the party Pokemon named by ref, which is
+// either its slot number or its name, or -1 if there is no such Pokemon.
+func partyIndex(config *Config, ref string) int {
+	if slot, err := strconv.Atoi(ref); err == nil {
+		if slot < 1 || slot > len(config.Party) {
+			return -1
+		}
+		return slot - 1
+	}
+	for i, pokemon := range config.Party {
+		if nameMatches(config, pokemon.Species.Names, pokemon.Name, ref) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findPokemon returns the trainer's Pokemon named by ref, which is either a
+// party slot number or a name, looking through the party before the boxes.
+func findPokemon(config *Config, ref string) *Pokemon {
+	if i := partyIndex(config, ref); i >= 0 {
+		return &config.Party[i]
+	}
+	for _, box := range config.Boxes {
+		for i := range box {
+			if nameMatches(config, box[i].Species.Names, box[i].Name, ref) {
+				return &box[i]
+			}
+		}
+	}
+	return nil
+}
+
+// describePokemon summarizes an owned Pokemon for listings.
+func describePokemon(config *Config, pokemon Pokemon) string {
+	description := fmt.Sprintf("%s%s Lv. %d HP %d/%d", pokemon.displayName(config), traitMarkers(pokemon.Gender, pokemon.Shiny),
+		pokemon.Level, pokemon.CurrentHP, pokemon.maxHP())
+	if pokemon.Status != capture.StatusNone {
+		description += " " + string(pokemon.Status)
+	}
+	return description
+}
+
+func commandParty(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usage("party [--json]")
+	}
+	if jsonOutput(config, flags) {
+		party := []pokemonSummary{}
+		for _, pokemon := range config.Party {
+			party = append(party, summarizePokemon(config, pokemon))
+		}
+		return printJSON(party)
+	}
+	if len(config.Party) == 0 {
+		fmt.Println("Your party is empty. Catch some Pokemon!")
+		return nil
+	}
+	fmt.Println("Your party:")
+	for i, pokemon := range config.Party {
+		fmt.Printf("  %d. %s\n", i+1, describePokemon(config, pokemon))
+	}
+	return nil
+}
+
+func commandBox(config *Config, args []string) error {
+	positional, flags, err := parseFlags(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return usage("box [n] [--json]")
+	}
+	if len(positional) == 0 {
+		if jsonOutput(config, flags) {
+			boxes := []boxSummary{}
+			for i := range config.Boxes {
+				boxes = append(boxes, summarizeBox(config, i+1))
+			}
+			return printJSON(boxes)
+		}
+		for i, box := range config.Boxes {
+			fmt.Printf("  Box %d: %d/%d\n", i+1, len(box), boxSize)
+		}
+		return nil
+	}
+	n, err := strconv.Atoi(positional[0])
+	if err != nil || n < 1 || n > len(config.Boxes) {
+		return usage(fmt.Sprintf("box [n] [--json], with n from 1 to %d", len(config.Boxes)))
+	}
+	if jsonOutput(config, flags) {
+		return printJSON(summarizeBox(config, n))
+	}
+	box := config.Boxes[n-1]
+	if len(box) == 0 {
+		fmt.Printf("Box %d is empty.\n", n)
+		return nil
+	}
+	fmt.Printf("Box %d:\n", n)
+	for i, pokemon := range box {
+		fmt.Printf("  %d. %s\n", i+1, describePokemon(config, pokemon))
+	}
+	return nil
+}
+
+func commandDeposit(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("deposit <pokemon>")
+	}
+	if config.Battle != nil {
+		return errors.New("You can't use the PC in the middle of a battle!")
+	}
+	index := partyIndex(config, args[1])
+	if index < 0 {
+		return suggest(args[1]+" isn't in your party.", args[1], ownedNames(config))
+	}
+	healthy := false
+	for i, pokemon := range config.Party {
+		if i != index && pokemon.CurrentHP > 0 {
+			healthy = true
+		}
+	}
+	if !healthy {
+		return errors.New("You can't deposit your last Pokemon able to battle!")
+	}
+	pokemon := config.Party[index]
+	// Pokemon are restored to full health in the PC.
+	pokemon.CurrentHP = pokemon.maxHP()
+	pokemon.Status = capture.StatusNone
+	for i, box := range config.Boxes {
+		if len(box) < boxSize {
+			config.Boxes[i] = append(box, pokemon)
+			config.Party = append(config.Party[:index], config.Party[index+1:]...)
+			fmt.Printf("%s was deposited in Box %d.\n", pokemon.displayName(config), i+1)
+			return nil
+		}
+	}
+	return errors.New("The PC boxes are full!")
+}
+
+func commandWithdraw(config *Config, args []string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return usage("withdraw <pokemon> or withdraw <box> <slot>")
+	}
+	if config.Battle != nil {
+		return errors.New("You can't use the PC in the middle of a battle!")
+	}
+	if len(config.Party) >= partySize {
+		return errors.New("Your party is full! Deposit a Pokemon first.")
+	}
+	boxIndex, slotIndex := -1, -1
+	if len(args) == 3 {
+		n, err := strconv.Atoi(args[1])
+		slot, slotErr := strconv.Atoi(args[2])
+		if err != nil || slotErr != nil || n < 1 || n > len(config.Boxes) || slot < 1 || slot > len(config.Boxes[n-1]) {
+			return fmt.Errorf("There is no Pokemon in Box %s slot %s.", args[1], args[2])
+		}
+		boxIndex, slotIndex = n-1, slot-1
+	} else {
+		for i, box := range config.Boxes {
+			for j, pokemon := range box {
+				if nameMatches(config, pokemon.Species.Names, pokemon.Name, args[1]) && boxIndex < 0 {
+					boxIndex, slotIndex = i, j
+				}
+			}
+		}
+		if boxIndex < 0 {
+			return suggest(args[1]+" isn't in the PC.", args[1], ownedNames(config))
+		}
+	}
+	box := config.Boxes[boxIndex]
+	pokemon := box[slotIndex]
+	config.Boxes[boxIndex] = append(box[:slotIndex], box[slotIndex+1:]...)
+	config.Party = append(config.Party, pokemon)
+	fmt.Printf("%s was withdrawn from Box %d.\n", pokemon.displayName(config), boxIndex+1)
+	return nil
+}
+
+func commandSwap(config *Config, args []string) error {
+	if len(args) != 3 {
+		return usage("swap <pokemon> <pokemon>")
+	}
+	if config.Battle != nil {
+		return errors.New("Use switch to change Pokemon in battle.")
+	}
+	a, b := partyIndex(config, args[1]), partyIndex(config, args[2])
+	for i, index := range []int{a, b} {
+		if index < 0 {
+			return suggest(args[i+1]+" isn't in your party.", args[i+1], ownedNames(config))
+		}
+	}
+	config.Party[a], config.Party[b] = config.Party[b], config.Party[a]
+	fmt.Printf("%s and %s swapped places.\n", config.Party[b].displayName(config), config.Party[a].displayName(config))
+	return nil
+}
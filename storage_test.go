@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestStore(t *testing.T) {
+	config := testConfig(1)
+	for range partySize {
+		if box, err := store(config, Pokemon{Pokemon: pokeapi.Pokemon{Name: "rattata"}}); err != nil || box != 0 {
+			t.Fatalf("[Expected, Received]: [0, %d] (%v)", box, err)
+		}
+	}
+	config.Boxes[0] = make([]Pokemon, boxSize)
+	box, err := store(config, Pokemon{Pokemon: pokeapi.Pokemon{Name: "pidgey"}})
+	if err != nil || box != 2 {
+		t.Fatalf("[Expected, Received]: [2, %d] (%v)", box, err)
+	}
+	if found := findPokemon(config, "pidgey"); found == nil || found != &config.Boxes[1][0] {
+		t.Errorf("expected to find pidgey in box 2")
+	}
+	if found := findPokemon(config, "3"); found != &config.Party[2] {
+		t.Errorf("expected to find party slot 3")
+	}
+	for i := range config.Boxes {
+		config.Boxes[i] = make([]Pokemon, boxSize)
+	}
+	if !storageFull(config) {
+		t.Errorf("expected storage to be full")
+	}
+	if _, err := store(config, Pokemon{}); err == nil {
+		t.Errorf("expected an error storing with no room left")
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jthughes/pokedexcli/internal/fuzzy"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+const (
+	// maxSuggestions is the most names suggested for a mistyped one.
+	maxSuggestions = 3
+	// nameIndexMaxAge is how long a name index cached on disk is used before
+	// it is fetched again.
+	nameIndexMaxAge = 30 * 24 * time.Hour
+)
+
+func defaultIndexDir() string {
+	dir, err := dataDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "index")
+}
+
+// nameIndex returns every name at an API endpoint, such as "pokemon". Names
+// are fetched once and cached in config.IndexDir.
+func nameIndex(config *Config, endpoint string) ([]string, error) {
+	if names, ok := config.NameIndex[endpoint]; ok {
+		return names, nil
+	}
+	if config.NameIndex == nil {
+		config.NameIndex = map[string][]string{}
+	}
+	path := ""
+	if config.IndexDir != "" {
+		path = filepath.Join(config.IndexDir, endpoint+".json")
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < nameIndexMaxAge {
+			data, err := os.ReadFile(path)
+			names := []string{}
+			if err == nil && json.Unmarshal(data, &names) == nil {
+				config.NameIndex[endpoint] = names
+				return names, nil
+			}
+		}
+	}
+	resources, err := pokeapi.GetResources(endpoint, config.Cache)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	config.NameIndex[endpoint] = names
+	if path != "" {
+		data, err := json.Marshal(names)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshall name index: %w", err)
+		}
+		if err := os.MkdirAll(config.IndexDir, 0o755); err != nil {
+			return nil, fmt.Errorf("unable to create index directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("unable to write name index: %w", err)
+		}
+	}
+	return names, nil
+}
+
+// didYouMean returns a hint naming the candidates closest to input, or "" if
+// none are close.
+func didYouMean(input string, candidates []string) string {
+	suggestions := fuzzy.Suggest(input, candidates, maxSuggestions)
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return "Did you mean " + suggestions[0] + "?"
+	}
+	last := len(suggestions) - 1
+	return "Did you mean " + strings.Join(suggestions[:last], ", ") + " or " + suggestions[last] + "?"
+}
+
+// printSuggestion prints a hint naming the candidates closest to input, if
+// any are close.
+func printSuggestion(input string, candidates []string) {
+	if hint := didYouMean(input, candidates); hint != "" {
+		fmt.Println(hint)
+	}
+}
+
+// ownedNames returns the names of the trainer's Pokemon, in the configured
+// language.
+func ownedNames(config *Config) []string {
+	names := []string{}
+	for _, pokemon := range config.Party {
+		names = append(names, pokemon.displayName(config))
+	}
+	for _, box := range config.Boxes {
+		for _, pokemon := range box {
+			names = append(names, pokemon.displayName(config))
+		}
+	}
+	return names
+}
+
+// printError prints an error returned by a command, as JSON when that is the
+// output format. When a name wasn't found, the closest names at the same API
+// endpoint are suggested.
+func printError(config *Config, err error) {
+	message := err.Error()
+	name, candidates := "", []string{}
+	var unknown *unknownCommandError
+	var suggestion *suggestionError
+	var notFound *pokeapi.NotFoundError
+	switch {
+	case errors.As(err, &unknown):
+		message = "Unknown command"
+		name, candidates = unknown.name, commandNames()
+	case errors.As(err, &suggestion):
+		name, candidates = suggestion.name, suggestion.candidates
+	case errors.As(err, &notFound):
+		message = strings.ToUpper(message[:1]) + message[1:] + "."
+		if names, err := nameIndex(config, notFound.Endpoint); err == nil {
+			name, candidates = notFound.Name, names
+		}
+	}
+	if config.Output == "json" {
+		summary := errorSummary{Error: message, Suggestions: fuzzy.Suggest(name, candidates, maxSuggestions)}
+		if err := printJSON(summary); err != nil {
+			fmt.Println(message)
+		}
+		return
+	}
+	fmt.Println(message)
+	printSuggestion(name, candidates)
+}
+
+// commandNames returns the names of the registered commands.
+func commandNames() []string {
+	return slices.Sorted(maps.Keys(commands))
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDidYouMean(t *testing.T) {
+	candidates := []string{"pikachu", "pichu", "raichu", "bulbasaur", "charmander"}
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "pikchu", expected: "Did you mean pichu or pikachu?"},
+		{input: "bulbsaur", expected: "Did you mean bulbasaur?"},
+		{input: "mewtwo", expected: ""},
+	}
+	for _, c := range cases {
+		if actual := didYouMean(c.input, candidates); actual != c.expected {
+			t.Errorf("[Expected, Received]: [%q, %q]", c.expected, actual)
+		}
+	}
+}
+
+func TestNameIndexUsesMemory(t *testing.T) {
+	config := testConfig(1)
+	config.NameIndex = map[string][]string{"pokemon": {"pikachu"}}
+	names, err := nameIndex(config, "pokemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "pikachu" {
+		t.Errorf("[Expected, Received]: [%v, %v]", []string{"pikachu"}, names)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"math/rand/v2"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+const (
+	// defaultShinyOdds is the chance, 1 in defaultShinyOdds, of a wild
+	// Pokemon being shiny, as in Generation VI onwards.
+	defaultShinyOdds = 4096
+	// shinyCharmRolls is the number of extra shiny rolls the Shiny Charm
+	// gives.
+	shinyCharmRolls = 2
+)
+
+// rollShiny decides whether a new wild Pokemon is shiny. Like the games, the
+// Shiny Charm rerolls instead of changing the odds.
+func rollShiny(settings Settings, rng *rand.Rand) bool {
+	rolls := 1
+	if settings.ShinyCharm {
+		rolls += shinyCharmRolls
+	}
+	for range rolls {
+		if rng.IntN(max(settings.ShinyOdds, 1)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// rollGender picks the gender of a new individual of species: "male",
+// "female", or "" for genderless species. GenderRate is the chance of being
+// female in eighths, or -1 for genderless.
+func rollGender(species pokeapi.PokemonSpecies, rng *rand.Rand) string {
+	if species.GenderRate < 0 {
+		return ""
+	}
+	if rng.IntN(8) < species.GenderRate {
+		return "female"
+	}
+	return "male"
+}
+
+// traitMarkers returns the gender and shiny symbols shown after a Pokemon's
+// name in listings, such as " ♀ ★".
+func traitMarkers(gender string, shiny bool) string {
+	markers := ""
+	switch gender {
+	case "male":
+		markers += " ♂"
+	case "female":
+		markers += " ♀"
+	}
+	if shiny {
+		markers += " ★"
+	}
+	return markers
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func TestRollGender(t *testing.T) {
+	cases := []struct {
+		rate     int
+		expected string
+	}{
+		{rate: -1, expected: ""},
+		{rate: 0, expected: "male"},
+		{rate: 8, expected: "female"},
+	}
+	rng := newRNG(1)
+	for _, c := range cases {
+		for range 20 {
+			if actual := rollGender(pokeapi.PokemonSpecies{GenderRate: c.rate}, rng); actual != c.expected {
+				t.Fatalf("rate %d: [Expected, Received]: ['%s', '%s']", c.rate, c.expected, actual)
+			}
+		}
+	}
+}
+
+func TestRollShiny(t *testing.T) {
+	cases := []struct {
+		settings Settings
+		expected float64
+	}{
+		{settings: Settings{ShinyOdds: 1}, expected: 1},
+		{settings: Settings{ShinyOdds: 64}, expected: 1.0 / 64},
+		{settings: Settings{ShinyOdds: 64, ShinyCharm: true}, expected: 1 - (63.0/64)*(63.0/64)*(63.0/64)},
+	}
+	rng := newRNG(3)
+	trials := 100_000
+	for _, c := range cases {
+		shiny := 0
+		for range trials {
+			if rollShiny(c.settings, rng) {
+				shiny += 1
+			}
+		}
+		if actual := float64(shiny) / float64(trials); actual < c.expected*0.9 || actual > c.expected*1.1 {
+			t.Errorf("%+v: [Expected, Received]: [%.4f, %.4f]", c.settings, c.expected, actual)
+		}
+	}
+}
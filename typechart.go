@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jthughes/pokedexcli/internal/battle"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// latestGeneration is the most recent main series generation.
+const latestGeneration = 9
+
+// typeChart is the complete type chart for a generation.
+type typeChart struct {
+	generation int
+	// types lists the types that exist in the generation, in ID order.
+	types []string
+	chart battle.TypeChart
+}
+
+// getTypeChart returns the type chart for the configured generation, building
+// it from the /type endpoint the first time it is needed.
+func getTypeChart(config *Config) (*typeChart, error) {
+	generation := config.Settings.Generation
+	if config.TypeChart != nil && config.TypeChart.generation == generation {
+		return config.TypeChart, nil
+	}
+	resources, err := pokeapi.GetResources("type", config.Cache)
+	if err != nil {
+		return nil, err
+	}
+	types := []pokeapi.Type{}
+	for _, resource := range resources {
+		pokemonType, err := pokeapi.GetType(resource.Name, config.Cache)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, pokemonType)
+	}
+	config.TypeChart = buildTypeChart(types, generation)
+	return config.TypeChart, nil
+}
+
+// buildTypeChart builds the chart for generation, leaving out types that had
+// not been introduced yet and using the damage relations of the time.
+func buildTypeChart(types []pokeapi.Type, generation int) *typeChart {
+	chart := &typeChart{generation: generation, chart: battle.TypeChart{}}
+	slices.SortFunc(types, func(a, b pokeapi.Type) int {
+		return a.ID - b.ID
+	})
+	for _, pokemonType := range types {
+		// Types without damage relations, such as "unknown" and "shadow",
+		// aren't used in battle.
+		relations := pokemonType.DamageRelations
+		if pokemonType.Generation.ID() > generation ||
+			len(relations.DoubleDamageTo)+len(relations.HalfDamageTo)+len(relations.NoDamageTo)+
+				len(relations.DoubleDamageFrom)+len(relations.HalfDamageFrom)+len(relations.NoDamageFrom) == 0 {
+			continue
+		}
+		chart.types = append(chart.types, pokemonType.Name)
+	}
+	for _, pokemonType := range types {
+		if !slices.Contains(chart.types, pokemonType.Name) {
+			continue
+		}
+		multipliers := map[string]float64{}
+		add := func(defenders []pokeapi.Resource, multiplier float64) {
+			for _, defender := range defenders {
+				if slices.Contains(chart.types, defender.Name) {
+					multipliers[defender.Name] = multiplier
+				}
+			}
+		}
+		relations := relationsIn(pokemonType, generation)
+		add(relations.DoubleDamageTo, 2)
+		add(relations.HalfDamageTo, 0.5)
+		add(relations.NoDamageTo, 0)
+		chart.chart[pokemonType.Name] = multipliers
+	}
+	return chart
+}
+
+// relationsIn returns the type's damage relations as they were in generation.
+func relationsIn(pokemonType pokeapi.Type, generation int) pokeapi.TypeRelations {
+	relations, until := pokemonType.DamageRelations, 0
+	for _, past := range pokemonType.PastDamageRelations {
+		id := past.Generation.ID()
+		if id >= generation && (until == 0 || id < until) {
+			relations, until = past.DamageRelations, id
+		}
+	}
+	return relations
+}
+
+// pokemonTypes returns the Pokemon's types as they were in generation.
+func pokemonTypes(pokemon pokeapi.Pokemon, generation int) []string {
+	types, until := pokemon.Types, 0
+	for _, past := range pokemon.PastTypes {
+		id := past.Generation.ID()
+		if id >= generation && (until == 0 || id < until) {
+			types, until = past.Types, id
+		}
+	}
+	names := []string{}
+	for _, pokemonType := range types {
+		names = append(names, pokemonType.Type.Name)
+	}
+	return names
+}
+
+// groupByMultiplier returns the types in each multiplier group, in the order
+// the groups are given.
+func groupByMultiplier(types []string, multiplier func(string) float64, groups []float64) map[float64][]string {
+	grouped := map[float64][]string{}
+	for _, name := range types {
+		value := multiplier(name)
+		if slices.Contains(groups, value) {
+			grouped[value] = append(grouped[value], name)
+		}
+	}
+	return grouped
+}
+
+func printGroups(grouped map[float64][]string, labels []string, groups []float64) {
+	for i, group := range groups {
+		if len(grouped[group]) > 0 {
+			fmt.Printf("  %s: %s\n", labels[i], strings.Join(grouped[group], ", "))
+		}
+	}
+}
+
+func commandType(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("type <name>")
+	}
+	chart, err := getTypeChart(config)
+	if err != nil {
+		return err
+	}
+	name := args[1]
+	if !slices.Contains(chart.types, name) {
+		message := fmt.Sprintf("There is no %s type in generation %d.", name, chart.generation)
+		return suggest(message, name, chart.types)
+	}
+	groups := []float64{2, 0.5, 0}
+	fmt.Printf("%s (generation %d)\n", name, chart.generation)
+	fmt.Println("Attacking:")
+	printGroups(groupByMultiplier(chart.types, func(defender string) float64 {
+		return chart.chart.Effectiveness(name, []string{defender})
+	}, groups), []string{"Super effective against", "Not very effective against", "No effect on"}, groups)
+	fmt.Println("Defending:")
+	printGroups(groupByMultiplier(chart.types, func(attacker string) float64 {
+		return chart.chart.Effectiveness(attacker, []string{name})
+	}, groups), []string{"Weak to", "Resists", "Immune to"}, groups)
+	return nil
+}
+
+func commandMatchup(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage("matchup <pokemon>")
+	}
+	pokemon, err := pokeapi.GetPokemon(args[1], config.Cache)
+	if err != nil {
+		return err
+	}
+	chart, err := getTypeChart(config)
+	if err != nil {
+		return err
+	}
+	types := pokemonTypes(pokemon, chart.generation)
+	groups := []float64{4, 2, 0.5, 0.25, 0}
+	fmt.Printf("%s (%s, generation %d)\n", localPokemonName(config, pokemon.Name), strings.Join(types, "/"), chart.generation)
+	printGroups(groupByMultiplier(chart.types, func(attacker string) float64 {
+		return chart.chart.Effectiveness(attacker, types)
+	}, groups), []string{"4x weak to", "2x weak to", "Resists (0.5x)", "Resists (0.25x)", "Immune to"}, groups)
+	return nil
+}
+
+func parseGeneration(value string) (int, error) {
+	generation, err := strconv.Atoi(value)
+	if err != nil || generation < 1 || generation > latestGeneration {
+		return 0, fmt.Errorf("expecting a generation from 1 to %d, got %q", latestGeneration, value)
+	}
+	return generation, nil
+}
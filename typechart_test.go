@@ -0,0 +1,95 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+func generation(id string) pokeapi.Resource {
+	return pokeapi.Resource{URL: "https://pokeapi.co/api/v2/generation/" + id + "/"}
+}
+
+func resources(names ...string) []pokeapi.Resource {
+	list := []pokeapi.Resource{}
+	for _, name := range names {
+		list = append(list, pokeapi.Resource{Name: name})
+	}
+	return list
+}
+
+func TestBuildTypeChart(t *testing.T) {
+	types := []pokeapi.Type{
+		{
+			ID: 8, Name: "ghost", Generation: generation("1"),
+			DamageRelations: pokeapi.TypeRelations{
+				DoubleDamageTo: resources("ghost", "psychic"),
+				NoDamageTo:     resources("normal"),
+			},
+			// Ghost moves couldn't hit Psychic types in the first generation.
+			PastDamageRelations: []pokeapi.TypePastRelations{{
+				Generation: generation("1"),
+				DamageRelations: pokeapi.TypeRelations{
+					DoubleDamageTo: resources("ghost"),
+					NoDamageTo:     resources("normal", "psychic"),
+				},
+			}},
+		},
+		{ID: 1, Name: "normal", Generation: generation("1"), DamageRelations: pokeapi.TypeRelations{NoDamageTo: resources("ghost")}},
+		{ID: 14, Name: "psychic", Generation: generation("1"), DamageRelations: pokeapi.TypeRelations{HalfDamageTo: resources("psychic")}},
+		{ID: 16, Name: "dragon", Generation: generation("1"), DamageRelations: pokeapi.TypeRelations{
+			DoubleDamageTo: resources("dragon"),
+			NoDamageTo:     resources("fairy"),
+		}},
+		{ID: 18, Name: "fairy", Generation: generation("6"), DamageRelations: pokeapi.TypeRelations{DoubleDamageTo: resources("dragon")}},
+		{ID: 10001, Name: "unknown", Generation: generation("2")},
+	}
+	cases := []struct {
+		generation int
+		types      []string
+		attack     string
+		defend     string
+		expected   float64
+	}{
+		{generation: 9, types: []string{"normal", "ghost", "psychic", "dragon", "fairy"}, attack: "ghost", defend: "psychic", expected: 2},
+		{generation: 9, types: []string{"normal", "ghost", "psychic", "dragon", "fairy"}, attack: "dragon", defend: "fairy", expected: 0},
+		{generation: 5, types: []string{"normal", "ghost", "psychic", "dragon"}, attack: "ghost", defend: "psychic", expected: 2},
+		{generation: 1, types: []string{"normal", "ghost", "psychic", "dragon"}, attack: "ghost", defend: "psychic", expected: 0},
+		{generation: 1, types: []string{"normal", "ghost", "psychic", "dragon"}, attack: "ghost", defend: "ghost", expected: 2},
+	}
+	for _, c := range cases {
+		chart := buildTypeChart(slices.Clone(types), c.generation)
+		if !slices.Equal(chart.types, c.types) {
+			t.Errorf("generation %d types: [Expected, Received]: [%v, %v]", c.generation, c.types, chart.types)
+		}
+		received := chart.chart.Effectiveness(c.attack, []string{c.defend})
+		if received != c.expected {
+			t.Errorf("generation %d %s vs %s: [Expected, Received]: [%v, %v]", c.generation, c.attack, c.defend, c.expected, received)
+		}
+	}
+}
+
+func TestPokemonTypes(t *testing.T) {
+	clefairy := pokeapi.Pokemon{
+		Types: []pokeapi.PokemonType{{Slot: 1, Type: pokeapi.Resource{Name: "fairy"}}},
+		PastTypes: []pokeapi.PokemonPastTypes{{
+			Generation: generation("5"),
+			Types:      []pokeapi.PokemonType{{Slot: 1, Type: pokeapi.Resource{Name: "normal"}}},
+		}},
+	}
+	cases := []struct {
+		generation int
+		expected   []string
+	}{
+		{generation: 1, expected: []string{"normal"}},
+		{generation: 5, expected: []string{"normal"}},
+		{generation: 6, expected: []string{"fairy"}},
+	}
+	for _, c := range cases {
+		received := pokemonTypes(clefairy, c.generation)
+		if !slices.Equal(received, c.expected) {
+			t.Errorf("generation %d: [Expected, Received]: [%v, %v]", c.generation, c.expected, received)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/jthughes/pokedexcli/internal/capture"
+	"github.com/jthughes/pokedexcli/internal/pokeapi"
+)
+
+// Wild Pokemon met outside of an encounter table, with free catch enabled,
+// are given a level in this range.
+const (
+	minWildLevel = 2
+	maxWildLevel = 50
+)
+
+// wildPokemon is the wild Pokemon the trainer is currently facing.
+type wildPokemon struct {
+	Pokemon pokeapi.Pokemon
+	Species pokeapi.PokemonSpecies
+	Level   int
+	IVs     Stats
+	Nature  Nature
+	Ability pokeapi.PokemonAbility
+	Gender  string
+	Shiny   bool
+	// KnownMoves are the moves the Pokemon can use in battle.
+	KnownMoves []string
+	CurrentHP  int
+	Status     capture.Status
+	// Turn counts the throws made at the Pokemon so far.
+	Turn int
+	// Method is how the Pokemon was encountered, such as "walk" or "surf".
+	Method string
+}
+
+func newWildPokemon(pokemon pokeapi.Pokemon, species pokeapi.PokemonSpecies, level int, ivs Stats, nature Nature) *wildPokemon {
+	wild := &wildPokemon{
+		Pokemon: pokemon,
+		Species: species,
+		Level:   level,
+		IVs:     ivs,
+		Nature:  nature,
+		// Wild Pokemon know the last moves they learned by levelling up.
+		KnownMoves: knownMoves(pokemon, level),
+	}
+	wild.CurrentHP = wild.maxHP()
+	return wild
+}
+
+// spawnWild creates a wild Pokemon at level with random IVs, nature, ability,
+// gender and shininess drawn from rng.
+func spawnWild(config *Config, rng *rand.Rand, pokemon pokeapi.Pokemon, species pokeapi.PokemonSpecies, level int) (*wildPokemon, error) {
+	nature, err := rollNature(config, rng)
+	if err != nil {
+		return nil, err
+	}
+	wild := newWildPokemon(pokemon, species, level, rollIVs(rng), nature)
+	wild.Ability = rollAbility(pokemon, rng)
+	wild.Gender = rollGender(species, rng)
+	wild.Shiny = rollShiny(config.Settings, rng)
+	return wild, nil
+}
+
+func (wild *wildPokemon) stat(name string) int {
+	return computeStat(wild.Pokemon, name, wild.IVs, nil, wild.Level, wild.Nature)
+}
+
+func (wild *wildPokemon) maxHP() int {
+	return wild.stat("hp")
+}
+
+func (wild *wildPokemon) describe(config *Config) string {
+	description := fmt.Sprintf("%s%s (Lv. %d) HP %d/%d", wild.displayName(config), traitMarkers(wild.Gender, wild.Shiny),
+		wild.Level, wild.CurrentHP, wild.maxHP())
+	if wild.Status != capture.StatusNone {
+		description += " [" + string(wild.Status) + "]"
+	}
+	return description
+}
+
+func commandWeaken(config *Config, args []string) error {
+	if len(args) != 1 {
+		return usage("weaken")
+	}
+	wild := config.Wild
+	if wild == nil {
+		return errors.New("There is no wild Pokemon to weaken.")
+	}
+	if config.Battle != nil {
+		return errors.New("You're in a battle! Use fight instead.")
+	}
+	// Like False Swipe, weakening never knocks the Pokemon out.
+	damage := 1 + config.RNG.IntN(max(wild.maxHP()/3, 1))
+	wild.CurrentHP = max(wild.CurrentHP-damage, 1)
+	fmt.Println("The wild " + wild.describe(config))
+	return nil
+}
+
+func commandInflict(config *Config, args []string) error {
+	if len(args) != 2 {
+		return usage(fmt.Sprintf("inflict <status>, one of %v", capture.Statuses))
+	}
+	wild := config.Wild
+	if wild == nil {
+		return errors.New("There is no wild Pokemon to inflict a status on.")
+	}
+	if config.Battle != nil {
+		return errors.New("You're in a battle! Use fight instead.")
+	}
+	status := capture.Status(args[1])
+	if !slices.Contains(capture.Statuses, status) {
+		return fmt.Errorf("Unknown status: %s, expecting one of %v", args[1], capture.Statuses)
+	}
+	if wild.Status != capture.StatusNone {
+		fmt.Println("But it failed! The wild " + wild.displayName(config) + " already has a status condition.")
+		return nil
+	}
+	wild.Status = status
+	fmt.Println("The wild " + wild.describe(config))
+	return nil
+}